@@ -0,0 +1,52 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+)
+
+// Credits holds the credits purchased and used by the account, in USD.
+type Credits struct {
+	TotalCredits float64 `json:"total_credits"`
+	TotalUsage   float64 `json:"total_usage"`
+}
+
+// Remaining returns the credits left on the account.
+func (c Credits) Remaining() float64 {
+	return c.TotalCredits - c.TotalUsage
+}
+
+// RateLimit is the number of requests allowed per interval, e.g. "10s".
+type RateLimit struct {
+	Requests int    `json:"requests"`
+	Interval string `json:"interval"`
+}
+
+// KeyInfo describes the API key used by the client.
+type KeyInfo struct {
+	Label string `json:"label"`
+	// Usage is the credits used by the key, in USD.
+	Usage        float64 `json:"usage"`
+	UsageDaily   float64 `json:"usage_daily"`
+	UsageWeekly  float64 `json:"usage_weekly"`
+	UsageMonthly float64 `json:"usage_monthly"`
+	// Limit is the credit limit of the key, nil when unlimited.
+	Limit *float64 `json:"limit"`
+	// LimitRemaining is the credits left before reaching Limit, nil when unlimited.
+	LimitRemaining *float64 `json:"limit_remaining"`
+	// LimitReset tells how often the limit resets: daily, weekly, monthly, or empty for never.
+	LimitReset        LimitReset `json:"limit_reset"`
+	IsFreeTier        bool       `json:"is_free_tier"`
+	IsProvisioningKey bool       `json:"is_provisioning_key"`
+	RateLimit         RateLimit  `json:"rate_limit"`
+}
+
+// GetCredits returns the credits of the account owning the client's API key.
+func (c *Client) GetCredits(ctx context.Context) (*Credits, error) {
+	return callData[*Credits](ctx, c, http.MethodGet, "/credits", nil)
+}
+
+// GetCurrentKey returns the usage, limits and rate limit of the client's API key.
+func (c *Client) GetCurrentKey(ctx context.Context) (*KeyInfo, error) {
+	return callData[*KeyInfo](ctx, c, http.MethodGet, "/key", nil)
+}
@@ -0,0 +1,97 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetCredits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/credits", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{"data":{"total_credits":100.5,"total_usage":25.25}}`))
+	}))
+	defer server.Close()
+
+	credits, err := NewClient(WithAPIKey("key"), WithBaseURL(server.URL)).GetCredits(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Credits{TotalCredits: 100.5, TotalUsage: 25.25}, credits)
+	assert.Equal(t, 75.25, credits.Remaining())
+}
+
+func TestClient_GetCurrentKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *KeyInfo
+	}{{
+		name: "limited key",
+		response: `{"data":{
+			"label": "sk-or-v1-abc...xyz",
+			"usage": 12.5,
+			"usage_daily": 1.5,
+			"usage_weekly": 5,
+			"usage_monthly": 12.5,
+			"limit": 50,
+			"limit_remaining": 37.5,
+			"limit_reset": "monthly",
+			"is_free_tier": false,
+			"is_provisioning_key": false,
+			"rate_limit": {"requests": 500, "interval": "10s"}
+		}}`,
+		want: &KeyInfo{
+			Label:          "sk-or-v1-abc...xyz",
+			Usage:          12.5,
+			UsageDaily:     1.5,
+			UsageWeekly:    5,
+			UsageMonthly:   12.5,
+			Limit:          ptr(50.0),
+			LimitRemaining: ptr(37.5),
+			LimitReset:     "monthly",
+			RateLimit:      RateLimit{Requests: 500, Interval: "10s"},
+		},
+	}, {
+		name:     "unlimited free tier key",
+		response: `{"data":{"label":"free","usage":0,"limit":null,"limit_remaining":null,"is_free_tier":true,"rate_limit":{"requests":20,"interval":"60s"}}}`,
+		want: &KeyInfo{
+			Label:      "free",
+			IsFreeTier: true,
+			RateLimit:  RateLimit{Requests: 20, Interval: "60s"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/key", r.URL.Path)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			key, err := NewClient(WithBaseURL(server.URL)).GetCurrentKey(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+
+	t.Run("api error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"User not found."}}`))
+		}))
+		defer server.Close()
+
+		key, err := NewClient(WithBaseURL(server.URL)).GetCurrentKey(context.Background())
+
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Nil(t, key)
+	})
+}
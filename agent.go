@@ -0,0 +1,167 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+const defaultAgentMaxSteps = 10
+
+// ErrMaxStepsExceeded is returned when an agent is still calling tools after its last step.
+var ErrMaxStepsExceeded = errors.New("max steps exceeded")
+
+// Agent runs a chat completion in a loop: each time the model calls tools, they
+// are executed and their results are sent back, until the model answers with T.
+type Agent[T any] struct {
+	request         ChatCompletionRequest[T]
+	maxSteps        int
+	concurrentTools bool
+	onStep          func(AgentStep[T])
+}
+
+// NewAgent creates an agent starting from the model, messages and tools of request.
+func NewAgent[T any](request *ChatCompletionRequest[T]) *Agent[T] {
+	return &Agent[T]{
+		request:  *request,
+		maxSteps: defaultAgentMaxSteps,
+	}
+}
+
+// WithMaxSteps bounds the number of completions the agent sends. Defaults to 10.
+func (a *Agent[T]) WithMaxSteps(maxSteps int) *Agent[T] {
+	a.maxSteps = maxSteps
+	return a
+}
+
+// WithConcurrentToolCalls executes the tools called in a single step concurrently.
+func (a *Agent[T]) WithConcurrentToolCalls(enabled bool) *Agent[T] {
+	a.concurrentTools = enabled
+	return a
+}
+
+// OnStep registers a callback invoked after each step.
+func (a *Agent[T]) OnStep(fn func(AgentStep[T])) *Agent[T] {
+	a.onStep = fn
+	return a
+}
+
+// AgentStep is a completion sent by an agent along with the tools it executed.
+type AgentStep[T any] struct {
+	// Number is the 1-based index of the step.
+	Number      int
+	Response    *Response[T]
+	ToolResults []ToolResult
+}
+
+// ToolResult is the outcome of a tool call executed by an agent.
+type ToolResult struct {
+	Call    ToolCall
+	Content string
+	// Err is the error returned by the tool. It is sent back to the model, which may recover from it.
+	Err error
+}
+
+// AgentResult is the outcome of an agent run.
+type AgentResult[T any] struct {
+	// Value is the final answer of the model.
+	Value *T
+	Steps []AgentStep[T]
+	// Transcript is the whole conversation, from the initial messages to the final answer.
+	Transcript []Message
+}
+
+// Run executes the agent. On error, the result is still returned with the steps
+// completed so far so that the run can be audited. When the request is bound to a
+// conversation, the whole exchange is appended to it once the model answered.
+func (a *Agent[T]) Run(ctx context.Context, client *Client) (*AgentResult[T], error) {
+	result := &AgentResult[T]{Transcript: slices.Clone(a.request.allMessages())}
+	history := len(result.Transcript) - len(a.request.messages)
+
+	for number := 1; number <= a.maxSteps; number++ {
+		req := a.request
+		req.messages = result.Transcript
+		req.conversation = nil
+
+		res, err := req.GenerateResponse(ctx, client)
+		if err != nil {
+			return result, err
+		}
+		result.Transcript = append(result.Transcript, res.Message())
+
+		step := AgentStep[T]{Number: number, Response: res}
+		if len(res.ToolCalls) > 0 {
+			step.ToolResults = a.callTools(ctx, res.ToolCalls)
+			for _, r := range step.ToolResults {
+				result.Transcript = append(result.Transcript, r.Message())
+			}
+		}
+		result.Steps = append(result.Steps, step)
+
+		if a.onStep != nil {
+			a.onStep(step)
+		}
+
+		if len(res.ToolCalls) == 0 {
+			if res.Value == nil {
+				return result, res.missingValueError()
+			}
+
+			result.Value = res.Value
+			if a.request.conversation != nil {
+				a.request.conversation.Append(result.Transcript[history:]...)
+			}
+			return result, nil
+		}
+	}
+
+	return result, fmt.Errorf("%w: %d", ErrMaxStepsExceeded, a.maxSteps)
+}
+
+func (a *Agent[T]) callTools(ctx context.Context, calls []ToolCall) []ToolResult {
+	results := make([]ToolResult, len(calls))
+
+	if !a.concurrentTools {
+		for i, call := range calls {
+			results[i] = callTool(ctx, a.request.tools, call)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = callTool(ctx, a.request.tools, call)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func callTool(ctx context.Context, tools []Tool, call ToolCall) ToolResult {
+	result := ToolResult{Call: call}
+
+	i := slices.IndexFunc(tools, func(t Tool) bool { return t.Name() == call.Function.Name })
+	if i < 0 {
+		result.Err = fmt.Errorf("unknown tool %s", call.Function.Name)
+		return result
+	}
+
+	result.Content, result.Err = tools[i].Call(ctx, call.Function.Arguments)
+	return result
+}
+
+// Message returns the tool message sending the result back to the model.
+func (r ToolResult) Message() ToolMessage {
+	content := r.Content
+	if r.Err != nil {
+		content = "error: " + r.Err.Error()
+	}
+
+	return ToolMessage{ToolCallID: r.Call.ID, Content: content}
+}
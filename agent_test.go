@@ -0,0 +1,149 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgent_Run(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	toolCalls := `{"choices":[{"finish_reason":"tool_calls","message":{"content":"","tool_calls":[
+		{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}},
+		{"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}
+	]}}]}`
+	answer := `{"choices":[{"finish_reason":"stop","message":{"content":"{\"answer\":\"5 degrees\"}"}}]}`
+
+	t.Run("executes tools until the final answer", func(t *testing.T) {
+		var bodies []map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(data, &body))
+			bodies = append(bodies, body)
+
+			if len(bodies) == 1 {
+				_, _ = w.Write([]byte(toolCalls))
+			} else {
+				_, _ = w.Write([]byte(answer))
+			}
+		}))
+		defer server.Close()
+
+		var steps []int
+		req := ChatCompletion[testResponse]().
+			Use(ModelGemini2_5FlashLite).
+			AppendMessages(UserMessage{Content: []Content{TextContent{Text: "Weather in Paris?"}}}).
+			WithTools(weatherTool())
+
+		res, err := NewAgent(req).
+			WithConcurrentToolCalls(true).
+			OnStep(func(step AgentStep[testResponse]) { steps = append(steps, step.Number) }).
+			Run(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &testResponse{Answer: "5 degrees"}, res.Value)
+		assert.Equal(t, []int{1, 2}, steps)
+		assert.Len(t, res.Steps, 2)
+
+		if assert.Len(t, res.Steps[0].ToolResults, 2) {
+			assert.JSONEq(t, `{"temperature":5}`, res.Steps[0].ToolResults[0].Content)
+			assert.EqualError(t, res.Steps[0].ToolResults[1].Err, "unknown tool get_time")
+		}
+
+		assert.Equal(t, []Message{
+			UserMessage{Content: []Content{TextContent{Text: "Weather in Paris?"}}},
+			AssistantMessage{ToolCalls: res.Steps[0].Response.ToolCalls},
+			ToolMessage{ToolCallID: "call_1", Content: `{"temperature":5}`},
+			ToolMessage{ToolCallID: "call_2", Content: "error: unknown tool get_time"},
+			AssistantMessage{Content: `{"answer":"5 degrees"}`},
+		}, res.Transcript)
+
+		if assert.Len(t, bodies, 2) {
+			messages := bodies[1]["messages"].([]any)
+			assert.Len(t, messages, 4)
+			assert.Equal(t, "assistant", messages[1].(map[string]any)["role"])
+			assert.Equal(t, "tool", messages[2].(map[string]any)["role"])
+			assert.Equal(t, "call_1", messages[2].(map[string]any)["tool_call_id"])
+		}
+
+		assert.Len(t, req.messages, 1, "the original request must not be modified")
+	})
+
+	t.Run("keeps the text sent along tool calls", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"choices":[{"finish_reason":"tool_calls","message":{"content":"Let me check the weather.","tool_calls":[
+					{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}
+				]}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(answer))
+		}))
+		defer server.Close()
+
+		req := ChatCompletion[testResponse]().
+			AppendMessages(UserMessage{Content: []Content{TextContent{Text: "Weather in Paris?"}}}).
+			WithTools(weatherTool())
+
+		res, err := NewAgent(req).Run(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &testResponse{Answer: "5 degrees"}, res.Value)
+		assert.Len(t, res.Steps, 2)
+		assert.Equal(t, AssistantMessage{
+			Content:   "Let me check the weather.",
+			ToolCalls: res.Steps[0].Response.ToolCalls,
+		}, res.Transcript[1])
+	})
+
+	t.Run("stops after max steps", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			_, _ = w.Write([]byte(toolCalls))
+		}))
+		defer server.Close()
+
+		req := ChatCompletion[testResponse]().WithTools(weatherTool())
+
+		res, err := NewAgent(req).WithMaxSteps(3).Run(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.True(t, errors.Is(err, ErrMaxStepsExceeded))
+		assert.Nil(t, res.Value)
+		assert.Len(t, res.Steps, 3)
+		assert.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("returns API errors with the partial result", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				_, _ = w.Write([]byte(toolCalls))
+				return
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"code":429,"message":"Rate limited"}}`))
+		}))
+		defer server.Close()
+
+		req := ChatCompletion[testResponse]().WithTools(weatherTool())
+
+		res, err := NewAgent(req).Run(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.Len(t, res.Steps, 1)
+		assert.Len(t, res.Transcript, 3)
+	})
+}
@@ -0,0 +1,155 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultModelCacheTTL = time.Hour
+
+// ErrUnsupportedCapability is returned by capability checks when a model cannot
+// handle a request, before it is sent.
+var ErrUnsupportedCapability = errors.New("unsupported capability")
+
+// WithCapabilityChecks makes the client check every chat completion request against
+// the metadata of its models before sending it. The models catalog is fetched from
+// OpenRouter and cached for ttl, one hour when ttl is not positive.
+func WithCapabilityChecks(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			ttl = defaultModelCacheTTL
+		}
+		c.models = &modelCache{ttl: ttl}
+	}
+}
+
+type modelCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	models    ModelList
+	fetchedAt time.Time
+}
+
+// modelInfo returns the metadata of a model from the cached catalog, refreshing it
+// when stale. Variants such as :free or :online fall back to their base model.
+func (c *Client) modelInfo(ctx context.Context, id Model) (ModelInfo, bool, error) {
+	cache := c.models
+	if cache == nil {
+		cache = &modelCache{}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.models == nil || time.Since(cache.fetchedAt) > cache.ttl {
+		models, err := c.ListModels(ctx)
+		if err != nil {
+			return ModelInfo{}, false, fmt.Errorf("error fetching models: %w", err)
+		}
+		cache.models, cache.fetchedAt = models, time.Now()
+	}
+
+	if m, ok := cache.models.Find(id); ok {
+		return m, true, nil
+	}
+	if base := id.base(); base != id {
+		m, ok := cache.models.Find(base)
+		return m, ok, nil
+	}
+
+	return ModelInfo{}, false, nil
+}
+
+// requirements lists what the models serving a request must support.
+type requirements struct {
+	inputs           []Modality
+	outputs          []Modality
+	structuredOutput bool
+	reasoning        bool
+	tools            bool
+	estimatedTokens  int
+}
+
+func (req requirements) check(m ModelInfo) error {
+	for _, modality := range req.inputs {
+		if !m.AcceptsInput(modality) {
+			return fmt.Errorf("%w: model %s does not accept %s inputs", ErrUnsupportedCapability, m.ID, modality)
+		}
+	}
+
+	for _, modality := range req.outputs {
+		if !m.ProducesOutput(modality) {
+			return fmt.Errorf("%w: model %s does not produce %s outputs", ErrUnsupportedCapability, m.ID, modality)
+		}
+	}
+
+	// response_format alone only means JSON mode, not schema enforcement.
+	if req.structuredOutput && !m.SupportsStructuredOutputs() {
+		return fmt.Errorf("%w: model %s does not support structured outputs", ErrUnsupportedCapability, m.ID)
+	}
+
+	if req.reasoning && !m.SupportsParameter(ParameterReasoning) {
+		return fmt.Errorf("%w: model %s does not support reasoning", ErrUnsupportedCapability, m.ID)
+	}
+
+	if req.tools && !m.SupportsTools() {
+		return fmt.Errorf("%w: model %s does not support tools", ErrUnsupportedCapability, m.ID)
+	}
+
+	if m.ContextLength > 0 && req.estimatedTokens > m.ContextLength {
+		return fmt.Errorf("%w: request needs about %d tokens but model %s has a context length of %d",
+			ErrUnsupportedCapability, req.estimatedTokens, m.ID, m.ContextLength)
+	}
+
+	return nil
+}
+
+// contentModality returns the input modality of a content part. Files reach the
+// model as is only with the native PDF engine; other engines parse them to text.
+func contentModality(c Content, nativeFiles bool) Modality {
+	switch c.(type) {
+	case ImageContent:
+		return ModalityImage
+	case FileContent:
+		if nativeFiles {
+			return ModalityFile
+		}
+		return ModalityText
+	case AudioContent:
+		return ModalityAudio
+	default:
+		return ModalityText
+	}
+}
+
+// estimateTokens roughly counts the tokens of the text in messages, assuming four
+// characters per token.
+func estimateTokens(messages []Message) int {
+	chars := 0
+	for _, m := range messages {
+		switch m := m.(type) {
+		case SystemMessage:
+			chars += len(m.Content)
+			for _, part := range m.Parts {
+				chars += len(part.Text)
+			}
+		case DeveloperMessage:
+			chars += len(m.Content)
+		case AssistantMessage:
+			chars += len(m.Content)
+		case ToolMessage:
+			chars += len(m.Content)
+		case UserMessage:
+			for _, c := range m.Content {
+				if text, ok := c.(TextContent); ok {
+					chars += len(text.Text)
+				}
+			}
+		}
+	}
+
+	return chars / 4
+}
@@ -0,0 +1,96 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_CheckCapabilities(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	var modelCalls, completionCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/models" {
+			modelCalls.Add(1)
+			_, _ = w.Write([]byte(modelsResponse))
+			return
+		}
+		completionCalls.Add(1)
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithCapabilityChecks(time.Hour))
+	llama := Model("meta-llama/llama-3-8b-instruct")
+	image := UserMessage{Content: []Content{TextContent{Text: "Describe"}, ImageContent{URL: "https://example.com/image.jpg"}}}
+	file := UserMessage{Content: []Content{FileContent{Filename: "invoice.pdf", Data: "https://example.com/invoice.pdf"}}}
+
+	tests := []struct {
+		name    string
+		req     *ChatCompletionRequest[testResponse]
+		wantErr string
+	}{
+		{"supported", ChatCompletion[testResponse]().Use(ModelGemini2_5FlashLite).AppendMessages(image).WithTools(weatherTool()).WithReasoningEffort(ReasoningEffort_LOW), ""},
+		{"variant of a known model", ChatCompletion[testResponse]().Use(ModelGemini2_5FlashLite + ":online").AppendMessages(image), ""},
+		{"unknown model", ChatCompletion[testResponse]().Use("openrouter/auto"), ""},
+		{"structured outputs", ChatCompletion[testResponse]().Use(llama), "model meta-llama/llama-3-8b-instruct does not support structured outputs"},
+		{"json mode only", ChatCompletion[testResponse]().Use("mistralai/mistral-7b-instruct"), "model mistralai/mistral-7b-instruct does not support structured outputs"},
+		{"image input", ChatCompletion[testResponse]().UseWithFallbacks(ModelGemini2_5FlashLite, llama).AppendMessages(image), "model meta-llama/llama-3-8b-instruct does not accept image inputs"},
+		{"audio input", ChatCompletion[testResponse]().UseWithFallbacks(ModelGemini2_5FlashLite, llama).AppendMessages(
+			UserMessage{Content: []Content{AudioContent{Data: "UklGRg==", Format: AudioFormatWAV}}},
+		), "model meta-llama/llama-3-8b-instruct does not accept audio inputs"},
+		// Parsed files pass the input check and only fail on structured outputs.
+		{"parsed file input", ChatCompletion[testResponse]().Use(llama).AppendMessages(file).WithFileParser(PDFEnginePDFText), "model meta-llama/llama-3-8b-instruct does not support structured outputs"},
+		{"native file input", ChatCompletion[testResponse]().Use(llama).AppendMessages(file).WithFileParser(PDFEngineNative), "model meta-llama/llama-3-8b-instruct does not accept file inputs"},
+		{"image output", ChatCompletion[testResponse]().Use(llama).WithModalities(ModalityImage, ModalityText), "model meta-llama/llama-3-8b-instruct does not produce image outputs"},
+		{"variant with tools", ChatCompletion[testResponse]().Use(ModelGemini2_5FlashLite + ":free").WithTools(weatherTool()), ""},
+		{"context length", ChatCompletion[testResponse]().Use(ModelGemini2_5FlashLite).AppendMessages(
+			SystemMessage{Content: strings.Repeat("a", 4*1048576)},
+		).WithMaxTokens(1), "request needs about 1048577 tokens but model google/gemini-2.5-flash-lite has a context length of 1048576"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completionCalls.Store(0)
+
+			_, err := tt.req.GenerateContentWith(client)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.EqualValues(t, 1, completionCalls.Load())
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrUnsupportedCapability)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Zero(t, completionCalls.Load(), "the request must not be sent")
+		})
+	}
+
+	assert.EqualValues(t, 1, modelCalls.Load(), "the catalog must be cached")
+
+	t.Run("explicit check", func(t *testing.T) {
+		err := ChatCompletion[testResponse]().Use(llama).WithTools(weatherTool()).
+			CheckCapabilities(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.ErrorIs(t, err, ErrUnsupportedCapability)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		modelCalls.Store(0)
+
+		_, err := ChatCompletion[testResponse]().Use(llama).GenerateContentWith(NewClient(WithBaseURL(server.URL)))
+
+		assert.NoError(t, err)
+		assert.Zero(t, modelCalls.Load())
+	})
+}
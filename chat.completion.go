@@ -1,11 +1,11 @@
 package openrouter
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
 
 	"github.com/orixa-group/open-router/schema"
 )
@@ -16,8 +16,21 @@ const (
 
 type ChatCompletionRequest[T any] struct {
 	model     Model
+	fallbacks []Model
 	messages  []Message
 	reasoning ReasoningEffort
+	stream    bool
+
+	conversation *Conversation
+	provider     *providerPreferences
+	sampling     samplingParameters
+	plugins      []plugin
+	modalities   []Modality
+	webSearch    *webSearchOptions
+
+	tools             []Tool
+	toolChoice        *ToolChoice
+	parallelToolCalls *bool
 }
 
 func ChatCompletion[T any]() *ChatCompletionRequest[T] {
@@ -32,84 +45,456 @@ func (r *ChatCompletionRequest[T]) WithReasoningEffort(value ReasoningEffort) *C
 
 func (r *ChatCompletionRequest[T]) Use(model Model) *ChatCompletionRequest[T] {
 	r.model = model
+	r.fallbacks = nil
+	return r
+}
+
+// UseWithFallbacks uses primary, falling back to the next models in order when it
+// is unavailable, rate limited or refuses to answer.
+func (r *ChatCompletionRequest[T]) UseWithFallbacks(primary Model, fallbacks ...Model) *ChatCompletionRequest[T] {
+	r.model = primary
+	r.fallbacks = fallbacks
+	return r
+}
+
+// WithTools declares tools the model can call.
+func (r *ChatCompletionRequest[T]) WithTools(tools ...Tool) *ChatCompletionRequest[T] {
+	r.tools = append(r.tools, tools...)
+	return r
+}
+
+// WithToolChoice controls whether and which tools the model calls.
+func (r *ChatCompletionRequest[T]) WithToolChoice(choice ToolChoice) *ChatCompletionRequest[T] {
+	r.toolChoice = &choice
+	return r
+}
+
+// WithParallelToolCalls allows or prevents the model from calling several tools at once.
+func (r *ChatCompletionRequest[T]) WithParallelToolCalls(enabled bool) *ChatCompletionRequest[T] {
+	r.parallelToolCalls = &enabled
+	return r
+}
+
+// WithTemperature sets the randomness of the output, between 0 and 2.
+func (r *ChatCompletionRequest[T]) WithTemperature(value float64) *ChatCompletionRequest[T] {
+	r.sampling.Temperature = &value
+	return r
+}
+
+// WithTopP limits sampling to the tokens whose cumulative probability reaches value, between 0 and 1.
+func (r *ChatCompletionRequest[T]) WithTopP(value float64) *ChatCompletionRequest[T] {
+	r.sampling.TopP = &value
+	return r
+}
+
+// WithTopK limits sampling to the value most likely tokens. 0 disables it.
+func (r *ChatCompletionRequest[T]) WithTopK(value int) *ChatCompletionRequest[T] {
+	r.sampling.TopK = &value
+	return r
+}
+
+// WithMinP sets the minimum probability of a token, relative to the most likely one, between 0 and 1.
+func (r *ChatCompletionRequest[T]) WithMinP(value float64) *ChatCompletionRequest[T] {
+	r.sampling.MinP = &value
+	return r
+}
+
+// WithTopA only samples tokens whose probability is high enough relative to the most likely one, between 0 and 1.
+func (r *ChatCompletionRequest[T]) WithTopA(value float64) *ChatCompletionRequest[T] {
+	r.sampling.TopA = &value
+	return r
+}
+
+// WithMaxTokens bounds the number of tokens generated.
+func (r *ChatCompletionRequest[T]) WithMaxTokens(value int) *ChatCompletionRequest[T] {
+	r.sampling.MaxTokens = &value
+	return r
+}
+
+// WithStop stops generation as soon as one of the sequences is generated.
+func (r *ChatCompletionRequest[T]) WithStop(sequences ...string) *ChatCompletionRequest[T] {
+	r.sampling.Stop = sequences
+	return r
+}
+
+// WithSeed makes sampling deterministic, for the models supporting it.
+func (r *ChatCompletionRequest[T]) WithSeed(value int) *ChatCompletionRequest[T] {
+	r.sampling.Seed = &value
+	return r
+}
+
+// WithFrequencyPenalty penalizes tokens by how often they already appear, between -2 and 2.
+func (r *ChatCompletionRequest[T]) WithFrequencyPenalty(value float64) *ChatCompletionRequest[T] {
+	r.sampling.FrequencyPenalty = &value
+	return r
+}
+
+// WithPresencePenalty penalizes tokens that already appear, between -2 and 2.
+func (r *ChatCompletionRequest[T]) WithPresencePenalty(value float64) *ChatCompletionRequest[T] {
+	r.sampling.PresencePenalty = &value
+	return r
+}
+
+// WithRepetitionPenalty penalizes repeated tokens from the input, between 0 and 2.
+func (r *ChatCompletionRequest[T]) WithRepetitionPenalty(value float64) *ChatCompletionRequest[T] {
+	r.sampling.RepetitionPenalty = &value
+	return r
+}
+
+// WithLogitBias adds a bias, between -100 and 100, to the likelihood of the given token IDs.
+func (r *ChatCompletionRequest[T]) WithLogitBias(bias map[int]float64) *ChatCompletionRequest[T] {
+	r.sampling.LogitBias = bias
+	return r
+}
+
+// WithModalities sets the kinds of output the model may produce, e.g. ModalityImage
+// and ModalityText for models generating images, returned in Response.Images.
+//
+// Models generating images do not follow JSON schemas, so requests asking for
+// images are sent without one: their text is decoded into T when it is valid JSON
+// and only kept in Response.Content otherwise.
+func (r *ChatCompletionRequest[T]) WithModalities(modalities ...Modality) *ChatCompletionRequest[T] {
+	r.modalities = modalities
+	return r
+}
+
+// generatesImages reports whether the request asks for image outputs.
+func (r ChatCompletionRequest[T]) generatesImages() bool {
+	return slices.Contains(r.modalities, ModalityImage)
+}
+
+// WithFileParser sets the engine parsing the PDFs sent in the conversation.
+func (r *ChatCompletionRequest[T]) WithFileParser(engine PDFEngine) *ChatCompletionRequest[T] {
+	r.setPlugin(plugin{ID: "file-parser", PDF: &pdfConfig{Engine: engine}})
+	return r
+}
+
+// setPlugin adds p to the request, replacing the plugin with the same ID if any.
+func (r *ChatCompletionRequest[T]) setPlugin(p plugin) {
+	for i := range r.plugins {
+		if r.plugins[i].ID == p.ID {
+			r.plugins[i] = p
+			return
+		}
+	}
+	r.plugins = append(r.plugins, p)
+}
+
+// WithWebSearch grounds the answer with web search results, through the web plugin.
+// The sources are returned by Response.Citations. Calling it again replaces the
+// previous configuration.
+func (r *ChatCompletionRequest[T]) WithWebSearch(search WebSearch) *ChatCompletionRequest[T] {
+	r.setPlugin(plugin{
+		ID:           "web",
+		Engine:       search.Engine,
+		MaxResults:   search.MaxResults,
+		SearchPrompt: search.SearchPrompt,
+	})
+	return r
+}
+
+// WithWebSearchContextSize sets how much search context models with native web
+// search retrieve.
+func (r *ChatCompletionRequest[T]) WithWebSearchContextSize(size SearchContextSize) *ChatCompletionRequest[T] {
+	r.webSearch = &webSearchOptions{SearchContextSize: size}
+	return r
+}
+
+// WithProviderOrder lists the providers to try first, in order.
+func (r *ChatCompletionRequest[T]) WithProviderOrder(providers ...string) *ChatCompletionRequest[T] {
+	r.providers().Order = providers
+	return r
+}
+
+// WithOnlyProviders restricts the providers allowed to serve the request.
+func (r *ChatCompletionRequest[T]) WithOnlyProviders(providers ...string) *ChatCompletionRequest[T] {
+	r.providers().Only = providers
+	return r
+}
+
+// WithIgnoredProviders excludes providers from serving the request.
+func (r *ChatCompletionRequest[T]) WithIgnoredProviders(providers ...string) *ChatCompletionRequest[T] {
+	r.providers().Ignore = providers
+	return r
+}
+
+// WithAllowFallbacks allows or prevents the use of providers outside the preferred ones.
+func (r *ChatCompletionRequest[T]) WithAllowFallbacks(allow bool) *ChatCompletionRequest[T] {
+	r.providers().AllowFallbacks = &allow
+	return r
+}
+
+// WithRequireParameters only routes to providers supporting every parameter of the request.
+func (r *ChatCompletionRequest[T]) WithRequireParameters(require bool) *ChatCompletionRequest[T] {
+	r.providers().RequireParameters = &require
+	return r
+}
+
+// WithDataCollection allows or excludes providers that may store or train on the data.
+func (r *ChatCompletionRequest[T]) WithDataCollection(policy DataCollection) *ChatCompletionRequest[T] {
+	r.providers().DataCollection = policy
+	return r
+}
+
+// WithZDR restricts the request to providers with a zero data retention policy.
+func (r *ChatCompletionRequest[T]) WithZDR(enabled bool) *ChatCompletionRequest[T] {
+	r.providers().ZDR = &enabled
+	return r
+}
+
+// WithProviderSort sorts providers by price, throughput or latency instead of load balancing.
+func (r *ChatCompletionRequest[T]) WithProviderSort(sort ProviderSort) *ChatCompletionRequest[T] {
+	r.providers().Sort = sort
 	return r
 }
 
+// WithQuantizations restricts providers to the given quantization levels.
+func (r *ChatCompletionRequest[T]) WithQuantizations(quantizations ...Quantization) *ChatCompletionRequest[T] {
+	r.providers().Quantizations = quantizations
+	return r
+}
+
+// WithMaxPrice excludes providers more expensive than price.
+func (r *ChatCompletionRequest[T]) WithMaxPrice(price MaxPrice) *ChatCompletionRequest[T] {
+	r.providers().MaxPrice = &price
+	return r
+}
+
+func (r *ChatCompletionRequest[T]) providers() *providerPreferences {
+	if r.provider == nil {
+		r.provider = &providerPreferences{}
+	}
+	return r.provider
+}
+
 func (r *ChatCompletionRequest[T]) AppendMessages(messages ...Message) *ChatCompletionRequest[T] {
 	r.messages = append(r.messages, messages...)
 	return r
 }
 
+// InConversation sends the history of conversation before the messages of the request.
+// Once the request is answered, its messages and the assistant reply are appended to it.
+func (r *ChatCompletionRequest[T]) InConversation(conversation *Conversation) *ChatCompletionRequest[T] {
+	r.conversation = conversation
+	return r
+}
+
+// allMessages returns the conversation history followed by the messages of the request.
+func (r ChatCompletionRequest[T]) allMessages() []Message {
+	if r.conversation == nil {
+		return r.messages
+	}
+
+	return append(r.conversation.Messages(), r.messages...)
+}
+
+// commit appends the messages of the answered request and the reply to its conversation.
+func (r ChatCompletionRequest[T]) commit(reply AssistantMessage) {
+	if r.conversation == nil {
+		return
+	}
+
+	r.conversation.Append(append(slices.Clone(r.messages), reply)...)
+}
+
+// GenerateContent sends the request with a default client using apiKey. Use
+// GenerateContentWithContext to cancel the call or set a deadline.
 func (r ChatCompletionRequest[T]) GenerateContent(apiKey string) (*T, error) {
-	return createChatCompletion(apiKey, r)
+	return r.GenerateContentWithContext(context.Background(), NewClient(WithAPIKey(apiKey)))
 }
 
-func (r ChatCompletionRequest[T]) MarshalJSON() ([]byte, error) {
-	var t T
-	s, err := schema.Generate(t)
+// GenerateContentWith sends the request through the given client.
+func (r ChatCompletionRequest[T]) GenerateContentWith(client *Client) (*T, error) {
+	return r.GenerateContentWithContext(context.Background(), client)
+}
+
+// GenerateContentWithContext is like GenerateContentWith but aborts the call when ctx is done.
+func (r ChatCompletionRequest[T]) GenerateContentWithContext(ctx context.Context, client *Client) (*T, error) {
+	res, err := r.GenerateResponse(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("error generating schema: %w", err)
+		return nil, err
+	}
+	if res.Value == nil {
+		return nil, res.missingValueError()
 	}
 
-	req := NewOpenRouterChatCompletionRequest(r.model, s, r.messages...)
-	req.SetReasoningEffort(r.reasoning)
+	return res.Value, nil
+}
 
-	return json.Marshal(req)
+// Validate checks the request locally. It is called before the request is sent.
+func (r ChatCompletionRequest[T]) Validate() error {
+	if len(r.fallbacks) > 0 && (r.model == "" || slices.Contains(r.fallbacks, "")) {
+		return fmt.Errorf("%w: fallback models cannot be empty", ErrInvalidRequest)
+	}
+
+	if err := r.sampling.validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	if r.provider != nil {
+		if err := r.provider.validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+	}
+
+	for _, p := range r.plugins {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+	}
+
+	for _, m := range r.allMessages() {
+		if m, ok := m.(UserMessage); ok {
+			for _, c := range m.Content {
+				if audio, ok := c.(AudioContent); ok {
+					if err := audio.validate(); err != nil {
+						return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
 }
 
-func createChatCompletion[T any](apiKey string, params ChatCompletionRequest[T]) (*T, error) {
-	url := baseURL + "/chat/completions"
+// CheckCapabilities checks the request against the metadata of its models, fetched
+// through client: input modalities, structured outputs, reasoning, tools and context
+// length. Models missing from the catalog are not checked. Clients created with
+// WithCapabilityChecks run these checks before sending every request.
+func (r ChatCompletionRequest[T]) CheckCapabilities(ctx context.Context, client *Client) error {
+	req := r.requirements()
 
-	payload, err := json.Marshal(params)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %w", err)
+	for _, model := range append([]Model{r.model}, r.fallbacks...) {
+		info, found, err := client.modelInfo(ctx, model)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		if err := req.check(info); err != nil {
+			return err
+		}
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+	return nil
+}
+
+func (r ChatCompletionRequest[T]) requirements() requirements {
+	messages := r.allMessages()
+
+	req := requirements{
+		structuredOutput: !r.generatesImages(),
+		reasoning:        r.reasoning != "" && r.reasoning != ReasoningEffort_NONE,
+		tools:            len(r.tools) > 0,
+		outputs:          r.modalities,
+		estimatedTokens:  estimateTokens(messages),
+	}
+	if r.sampling.MaxTokens != nil {
+		req.estimatedTokens += *r.sampling.MaxTokens
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
 
-	resp, err := http.DefaultClient.Do(req)
+	nativeFiles := slices.ContainsFunc(r.plugins, func(p plugin) bool {
+		return p.PDF != nil && p.PDF.Engine == PDFEngineNative
+	})
+	for _, m := range messages {
+		if m, ok := m.(UserMessage); ok {
+			for _, c := range m.Content {
+				if modality := contentModality(c, nativeFiles); !slices.Contains(req.inputs, modality) {
+					req.inputs = append(req.inputs, modality)
+				}
+			}
+		}
+	}
+
+	return req
+}
+
+// preflight validates the request and, when the client asks for it, checks its capabilities.
+func (r ChatCompletionRequest[T]) preflight(ctx context.Context, client *Client) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	if client.models != nil {
+		return r.CheckCapabilities(ctx, client)
+	}
+
+	return nil
+}
+
+// GenerateResponse is like GenerateContentWithContext but returns the full response,
+// including the usage and the provider that served the completion.
+func (r ChatCompletionRequest[T]) GenerateResponse(ctx context.Context, client *Client) (*Response[T], error) {
+	res, err := createChatCompletion(ctx, client, r)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	r.commit(res.Message())
+
+	return res, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (r ChatCompletionRequest[T]) MarshalJSON() ([]byte, error) {
+	var t T
+	s, err := schema.Generate(t)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response body: %w", err)
+		return nil, fmt.Errorf("error generating schema: %w", err)
 	}
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
-		var respErr apiError
-		if err := json.Unmarshal(body, &respErr); err != nil {
-			return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	req := NewOpenRouterChatCompletionRequest(r.model, s, r.allMessages()...)
+	req.SetReasoningEffort(r.reasoning)
+	req.Stream = r.stream
+	if r.generatesImages() {
+		req.ResponseFormat = nil
+	}
+
+	if len(r.tools) > 0 {
+		if req.Tools, err = newToolDefinitions(r.tools); err != nil {
+			return nil, err
 		}
+	}
+	if len(r.fallbacks) > 0 {
+		req.Models = append([]Model{r.model}, r.fallbacks...)
+	}
+	req.samplingParameters = r.sampling
+	req.Provider = r.provider
+	req.Plugins = r.plugins
+	req.Modalities = r.modalities
+	req.WebSearchOptions = r.webSearch
+	req.ToolChoice = r.toolChoice
+	req.ParallelToolCalls = r.parallelToolCalls
+
+	return json.Marshal(req)
+}
 
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, respErr.Error.Message)
+func createChatCompletion[T any](ctx context.Context, client *Client, params ChatCompletionRequest[T]) (*Response[T], error) {
+	if err := params.preflight(ctx, client); err != nil {
+		return nil, err
 	}
 
-	var result struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			}
-		} `json:"choices"`
+	body, err := client.call(ctx, http.MethodPost, "/chat/completions", params)
+	if err != nil {
+		return nil, err
 	}
+
+	var result chatCompletionResponse
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("error unmarshaling response: %w", err)
 	}
 
-	var t T
-	if len(result.Choices) == 0 {
-		return nil, fmt.Errorf("unexpected error: empty response")
+	res, err := newResponse[T](result)
+	if err != nil {
+		return nil, err
 	}
-	if err := json.Unmarshal([]byte(result.Choices[0].Message.Content), &t); err != nil {
-		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	res.RequestedModel = params.model
+	res.Fallbacks = params.fallbacks
+
+	if client.generationPolling != nil && res.ID != "" {
+		res.Generation, res.GenerationErr = client.pollGeneration(ctx, res.ID)
 	}
 
-	return &t, nil
+	return res, nil
 }
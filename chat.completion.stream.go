@@ -0,0 +1,296 @@
+package openrouter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxStreamLineSize bounds the size of a single server-sent event line.
+const maxStreamLineSize = 10 << 20
+
+// Stream sends the request with streaming enabled and returns a Stream reading
+// the completion as it is generated. The caller must close the stream.
+func (r ChatCompletionRequest[T]) Stream(ctx context.Context, client *Client) (*Stream[T], error) {
+	if err := r.preflight(ctx, client); err != nil {
+		return nil, err
+	}
+	r.stream = true
+
+	resp, err := client.open(ctx, http.MethodPost, "/chat/completions", r)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+
+	return &Stream[T]{ctx: ctx, client: client, model: r.model, fallbacks: r.fallbacks, body: resp.Body, scanner: scanner, commit: r.commit}, nil
+}
+
+type chatCompletionChunk struct {
+	ID       string `json:"id"`
+	Model    Model  `json:"model"`
+	Provider string `json:"provider"`
+	Created  int64  `json:"created"`
+	Choices  []struct {
+		Delta struct {
+			Content     string               `json:"content"`
+			Images      []generatedImagePart `json:"images"`
+			Annotations []Annotation         `json:"annotations"`
+			ToolCalls   []toolCallDelta      `json:"tool_calls"`
+		} `json:"delta"`
+		FinishReason       FinishReason `json:"finish_reason"`
+		NativeFinishReason string       `json:"native_finish_reason"`
+	} `json:"choices"`
+	Usage *Usage    `json:"usage"`
+	Error *APIError `json:"error"`
+}
+
+// toolCallDelta is a fragment of a streamed tool call. The first fragment of a
+// call holds its ID and name, the following ones the rest of its arguments.
+type toolCallDelta struct {
+	Index    int          `json:"index"`
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// Stream reads a streamed chat completion, one text delta at a time.
+//
+//	for stream.Next() {
+//		fmt.Print(stream.Delta())
+//		render(stream.Partial())
+//	}
+//	if err := stream.Err(); err != nil { ... }
+type Stream[T any] struct {
+	ctx         context.Context
+	client      *Client
+	model       Model
+	fallbacks   []Model
+	commit      func(AssistantMessage)
+	body        io.ReadCloser
+	scanner     *bufio.Scanner
+	content     strings.Builder
+	images      []generatedImagePart
+	annotations []Annotation
+	toolCalls   []ToolCall
+	meta        chatCompletionResponse
+	delta       string
+	partial     *T
+	done        bool
+	err         error
+	response    *Response[T]
+}
+
+// Next advances the stream to the next text delta. It returns false once the
+// completion is over or an error occurred, which Err then reports.
+func (s *Stream[T]) Next() bool {
+	if s.done || s.err != nil {
+		return false
+	}
+
+	for {
+		data, ok := s.nextEvent()
+		if !ok {
+			return false
+		}
+		if data == "[DONE]" {
+			s.done = true
+			return false
+		}
+
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			s.err = fmt.Errorf("error unmarshaling stream chunk: %w", err)
+			return false
+		}
+		if chunk.Error != nil {
+			chunk.Error.StatusCode = chunk.Error.streamStatus()
+			s.err = chunk.Error
+			return false
+		}
+		s.collect(chunk)
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		s.delta = chunk.Choices[0].Delta.Content
+		s.content.WriteString(s.delta)
+
+		var t T
+		if err := decodePartial(s.content.String(), &t); err == nil {
+			s.partial = &t
+		}
+
+		return true
+	}
+}
+
+// collect keeps the metadata spread across chunks for the final response.
+func (s *Stream[T]) collect(chunk chatCompletionChunk) {
+	if chunk.ID != "" {
+		s.meta.ID = chunk.ID
+		s.meta.Model = chunk.Model
+		s.meta.Provider = chunk.Provider
+		s.meta.Created = chunk.Created
+	}
+	if chunk.Usage != nil {
+		s.meta.Usage = chunk.Usage
+	}
+	if len(chunk.Choices) > 0 {
+		s.images = append(s.images, chunk.Choices[0].Delta.Images...)
+		s.annotations = append(s.annotations, chunk.Choices[0].Delta.Annotations...)
+		for _, d := range chunk.Choices[0].Delta.ToolCalls {
+			s.collectToolCall(d)
+		}
+	}
+	if len(chunk.Choices) > 0 && chunk.Choices[0].FinishReason != "" {
+		s.meta.Choices = []chatCompletionChoice{{
+			FinishReason:       chunk.Choices[0].FinishReason,
+			NativeFinishReason: chunk.Choices[0].NativeFinishReason,
+		}}
+	}
+}
+
+// collectToolCall merges a tool call fragment into the call at its index.
+func (s *Stream[T]) collectToolCall(d toolCallDelta) {
+	if d.Index < 0 {
+		return
+	}
+	for len(s.toolCalls) <= d.Index {
+		s.toolCalls = append(s.toolCalls, ToolCall{})
+	}
+
+	call := &s.toolCalls[d.Index]
+	if d.ID != "" {
+		call.ID = d.ID
+	}
+	if d.Type != "" {
+		call.Type = d.Type
+	}
+	if d.Function.Name != "" {
+		call.Function.Name = d.Function.Name
+	}
+	call.Function.Arguments += d.Function.Arguments
+}
+
+// nextEvent returns the data of the next server-sent event, skipping comments
+// such as the ": OPENROUTER PROCESSING" keep-alives.
+func (s *Stream[T]) nextEvent() (string, bool) {
+	var data []string
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				return strings.Join(data, "\n"), true
+			}
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		if ctxErr := s.ctx.Err(); ctxErr != nil {
+			s.err = ctxErr
+		} else {
+			s.err = fmt.Errorf("error reading stream: %w", err)
+		}
+		return "", false
+	}
+	if len(data) > 0 {
+		return strings.Join(data, "\n"), true
+	}
+
+	s.done = true
+	return "", false
+}
+
+// Delta returns the text received by the last call to Next.
+func (s *Stream[T]) Delta() string {
+	return s.delta
+}
+
+// Content returns all the text received so far.
+func (s *Stream[T]) Content() string {
+	return s.content.String()
+}
+
+// Partial returns the best-effort decoding of the text received so far, or nil
+// while nothing could be decoded yet. Fields still being generated may be truncated.
+func (s *Stream[T]) Partial() *T {
+	return s.partial
+}
+
+// Result decodes the complete response once Next returned false.
+func (s *Stream[T]) Result() (*T, error) {
+	res, err := s.Response()
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Value, nil
+}
+
+// Response is like Result but returns the full response, including the usage
+// sent in the last chunks and, when the client was created with WithGenerationStats,
+// the stats of the generation. The first successful call appends the reply to the
+// conversation of the request, if any.
+func (s *Stream[T]) Response() (*Response[T], error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.response != nil {
+		return s.response, nil
+	}
+	if !s.done {
+		return nil, fmt.Errorf("stream is not over")
+	}
+	if s.content.Len() == 0 && len(s.images) == 0 && len(s.toolCalls) == 0 {
+		return nil, fmt.Errorf("unexpected error: empty response")
+	}
+
+	result := s.meta
+	if len(result.Choices) == 0 {
+		result.Choices = []chatCompletionChoice{{}}
+	}
+	result.Choices[0].Message.Content = s.content.String()
+	result.Choices[0].Message.Images = s.images
+	result.Choices[0].Message.Annotations = s.annotations
+	result.Choices[0].Message.ToolCalls = s.toolCalls
+
+	res, err := newResponse[T](result)
+	if err != nil {
+		return nil, err
+	}
+	res.RequestedModel = s.model
+	res.Fallbacks = s.fallbacks
+	if s.client.generationPolling != nil && res.ID != "" {
+		res.Generation, res.GenerationErr = s.client.pollGeneration(s.ctx, res.ID)
+	}
+	if s.commit != nil {
+		s.commit(res.Message())
+		s.commit = nil
+	}
+	s.response = res
+
+	return res, nil
+}
+
+// Err returns the error that stopped the stream, if any.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close releases the underlying connection.
+func (s *Stream[T]) Close() error {
+	return s.body.Close()
+}
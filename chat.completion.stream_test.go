@@ -0,0 +1,156 @@
+package openrouter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_Stream(t *testing.T) {
+	type testResponse struct {
+		Summary string   `json:"summary"`
+		Tags    []string `json:"tags"`
+	}
+
+	newServer := func(events string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"stream":true`)
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte(events))
+		}))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		server := newServer(": OPENROUTER PROCESSING\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"summary\\\":\\\"It \"}}]}\n\n" +
+			": OPENROUTER PROCESSING\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"content\":\"works\\\",\\\"tags\\\":[\\\"go\"}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"content\":\"\\\"]}\"},\"finish_reason\":\"stop\"}]}\n\n" +
+			"data: {\"id\":\"gen-123\",\"model\":\"google/gemini-2.5-flash-lite\",\"provider\":\"Google\",\"choices\":[],\"usage\":{\"prompt_tokens\":10,\"completion_tokens\":5,\"total_tokens\":15}}\n\n" +
+			"data: [DONE]\n\n")
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().
+			Use(ModelGemini2_5FlashLite).
+			Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		var deltas []string
+		var partials []testResponse
+		for stream.Next() {
+			deltas = append(deltas, stream.Delta())
+			partials = append(partials, *stream.Partial())
+		}
+
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []string{`{"summary":"It `, `works","tags":["go`, `"]}`}, deltas)
+		assert.Equal(t, []testResponse{
+			{Summary: "It "},
+			{Summary: "It works", Tags: []string{"go"}},
+			{Summary: "It works", Tags: []string{"go"}},
+		}, partials)
+		assert.Equal(t, `{"summary":"It works","tags":["go"]}`, stream.Content())
+
+		res, err := stream.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, &testResponse{Summary: "It works", Tags: []string{"go"}}, res)
+
+		resp, err := stream.Response()
+		assert.NoError(t, err)
+		assert.Equal(t, "gen-123", resp.ID)
+		assert.Equal(t, "Google", resp.Provider)
+		assert.Equal(t, FinishReasonStop, resp.FinishReason)
+		assert.Equal(t, 15, resp.Usage.TotalTokens)
+	})
+
+	t.Run("tool calls", func(t *testing.T) {
+		server := newServer("data: {\"choices\":[{\"delta\":{\"content\":\"Let me check.\"}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"tool_calls\":[{\"index\":0,\"id\":\"call_1\",\"type\":\"function\",\"function\":{\"name\":\"get_weather\",\"arguments\":\"\"}}]}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"tool_calls\":[{\"index\":0,\"function\":{\"arguments\":\"{\\\"city\\\":\"}}]}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{\"tool_calls\":[{\"index\":0,\"function\":{\"arguments\":\"\\\"Paris\\\"}\"}},{\"index\":1,\"id\":\"call_2\",\"type\":\"function\",\"function\":{\"name\":\"get_weather\",\"arguments\":\"{}\"}}]}}]}\n\n" +
+			"data: {\"choices\":[{\"delta\":{},\"finish_reason\":\"tool_calls\"}]}\n\n" +
+			"data: [DONE]\n\n")
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().
+			WithTools(weatherTool()).
+			Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		for stream.Next() {
+		}
+		res, err := stream.Response()
+
+		assert.NoError(t, err)
+		assert.Nil(t, res.Value)
+		assert.Equal(t, FinishReasonToolCalls, res.FinishReason)
+		assert.Equal(t, "Let me check.", res.Content)
+		assert.Equal(t, []ToolCall{
+			{ID: "call_1", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+			{ID: "call_2", Type: "function", Function: FunctionCall{Name: "get_weather", Arguments: `{}`}},
+		}, res.ToolCalls)
+	})
+
+	t.Run("mid-stream error", func(t *testing.T) {
+		server := newServer("data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"summary\\\"\"}}]}\n\n" +
+			"data: {\"error\":{\"code\":502,\"message\":\"Provider disconnected\"},\"choices\":[{\"delta\":{\"content\":\"\"},\"finish_reason\":\"error\"}]}\n\n")
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		for stream.Next() {
+		}
+
+		assert.EqualError(t, stream.Err(), "API error: 502 - Provider disconnected")
+		assert.ErrorIs(t, stream.Err(), ErrProviderUnavailable)
+
+		res, err := stream.Result()
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("mid-stream error with string code", func(t *testing.T) {
+		server := newServer("data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"summary\\\"\"}}]}\n\n" +
+			"data: {\"id\":\"cmpl-abc123\",\"object\":\"chat.completion.chunk\",\"created\":1234567890,\"model\":\"gpt-3.5-turbo\",\"provider\":\"openai\"," +
+			"\"error\":{\"code\":\"server_error\",\"message\":\"Provider disconnected unexpectedly\"}," +
+			"\"choices\":[{\"index\":0,\"delta\":{\"content\":\"\"},\"finish_reason\":\"error\"}]}\n\n")
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		for stream.Next() {
+		}
+
+		var apiErr *APIError
+		if assert.ErrorAs(t, stream.Err(), &apiErr) {
+			assert.Equal(t, "server_error", apiErr.CodeName)
+			assert.Equal(t, "Provider disconnected unexpectedly", apiErr.Message)
+		}
+		assert.ErrorIs(t, stream.Err(), ErrProviderUnavailable)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"No auth credentials found"}}`))
+		}))
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Nil(t, stream)
+	})
+}
@@ -2,10 +2,12 @@ package openrouter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
@@ -120,6 +122,62 @@ func TestGenerateContent(t *testing.T) {
 	})
 }
 
+func TestGenerateResponse(t *testing.T) {
+	type testResponse struct {
+		Summary string `json:"summary"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"id": "gen-123",
+			"model": "google/gemini-2.5-flash-lite",
+			"provider": "Google",
+			"created": 1735689600,
+			"choices": [{
+				"message": {"content": "{\"summary\": \"It works\"}"},
+				"finish_reason": "stop",
+				"native_finish_reason": "STOP"
+			}],
+			"usage": {
+				"prompt_tokens": 10,
+				"completion_tokens": 5,
+				"total_tokens": 15,
+				"cost": 0.0001,
+				"prompt_tokens_details": {"cached_tokens": 8},
+				"completion_tokens_details": {"reasoning_tokens": 2}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	res, err := ChatCompletion[testResponse]().
+		Use(ModelGemini2_5FlashLite).
+		GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Response[testResponse]{
+		ID:                 "gen-123",
+		Model:              ModelGemini2_5FlashLite,
+		RequestedModel:     ModelGemini2_5FlashLite,
+		Provider:           "Google",
+		Created:            1735689600,
+		FinishReason:       FinishReasonStop,
+		NativeFinishReason: "STOP",
+		Usage: Usage{
+			PromptTokens:            10,
+			CompletionTokens:        5,
+			TotalTokens:             15,
+			Cost:                    0.0001,
+			PromptTokensDetails:     &PromptTokensDetails{CachedTokens: 8},
+			CompletionTokensDetails: &CompletionTokensDetails{ReasoningTokens: 2},
+		},
+		Content: `{"summary": "It works"}`,
+		Value:   &testResponse{Summary: "It works"},
+	}, res)
+	assert.Equal(t, 8, res.Usage.CachedTokens())
+	assert.Zero(t, Usage{}.CachedTokens())
+}
+
 func TestChatCompletionRequest_MarshalJSON(t *testing.T) {
 	req := ChatCompletion[struct {
 		Summary string   `json:"summary"`
@@ -160,6 +218,72 @@ func TestChatCompletionRequest_MarshalJSON(t *testing.T) {
 	assert.Contains(t, props, "tags")
 }
 
+func TestChatCompletionRequest_UseWithFallbacks(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body struct {
+			Model  string   `json:"model"`
+			Models []string `json:"models"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, ModelClaudeSonnet4_5, body.Model)
+		assert.Equal(t, []string{ModelClaudeSonnet4_5, ModelChatGpt5_2, ModelGemini3Pro}, body.Models)
+
+		_, _ = w.Write([]byte(`{"model":"openai/gpt-5.2","choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	res, err := ChatCompletion[testResponse]().
+		UseWithFallbacks(ModelClaudeSonnet4_5, ModelChatGpt5_2, ModelGemini3Pro).
+		GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Model(ModelClaudeSonnet4_5), res.RequestedModel)
+	assert.Equal(t, Model(ModelChatGpt5_2), res.Model)
+	assert.Equal(t, []Model{ModelChatGpt5_2, ModelGemini3Pro}, res.Fallbacks)
+	assert.True(t, res.UsedFallback())
+
+	t.Run("use resets fallbacks", func(t *testing.T) {
+		data, err := json.Marshal(ChatCompletion[testResponse]().UseWithFallbacks(ModelClaudeSonnet4_5, ModelChatGpt5_2).Use(ModelGemini3Pro))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), `"models"`)
+	})
+
+	t.Run("empty fallback", func(t *testing.T) {
+		err := ChatCompletion[testResponse]().UseWithFallbacks(ModelClaudeSonnet4_5, "").Validate()
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
+
+func TestResponse_UsedFallback(t *testing.T) {
+	tests := []struct {
+		requested Model
+		fallbacks []Model
+		served    Model
+		want      bool
+	}{
+		{ModelChatGpt5_2, []Model{ModelClaudeSonnet4_5}, ModelChatGpt5_2, false},
+		{ModelChatGpt5_2, []Model{ModelClaudeSonnet4_5}, "openai/gpt-5.2-2025-12-11", false},
+		{ModelChatGpt5_2, []Model{ModelClaudeSonnet4_5}, ModelClaudeSonnet4_5, true},
+		{ModelChatGpt5_2, []Model{ModelClaudeSonnet4_5}, "anthropic/claude-sonnet-4.5-20250929", true},
+		{"openai/gpt-5", []Model{"openai/gpt-5-mini"}, "openai/gpt-5-mini", true},
+		{"openai/gpt-5", []Model{"openai/gpt-5-mini"}, "openai/gpt-5-2025-08-07", false},
+		{"openai/gpt-5:online", []Model{ModelClaudeSonnet4_5 + ":online"}, "openai/gpt-5", false},
+		{"openai/gpt-5:online", []Model{ModelClaudeSonnet4_5 + ":online"}, ModelClaudeSonnet4_5, true},
+		{ModelChatGpt5_2, nil, ModelClaudeSonnet4_5, false},
+		{ModelChatGpt5_2, []Model{ModelClaudeSonnet4_5}, "", false},
+	}
+
+	for _, tt := range tests {
+		res := Response[struct{}]{RequestedModel: tt.requested, Fallbacks: tt.fallbacks, Model: tt.served}
+		assert.Equal(t, tt.want, res.UsedFallback(), "%s with fallbacks %v served by %s", tt.requested, tt.fallbacks, tt.served)
+	}
+}
+
 func TestChatCompletionRequest_Effor(t *testing.T) {
 	apiKey := os.Getenv("OPENROUTER_TEST_API_KEY")
 
@@ -0,0 +1,213 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client holds the configuration shared by every call made to the OpenRouter API.
+// A Client is safe for concurrent use and several differently configured clients
+// can live side by side in the same process.
+type Client struct {
+	apiKey      string
+	httpClient  *http.Client
+	baseURL     string
+	headers     http.Header
+	userAgent   string
+	retryPolicy RetryPolicy
+	models      *modelCache
+
+	generationPolling *generationPolling
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// NewClient creates a Client. Without options, it targets the public OpenRouter API
+// through http.DefaultClient.
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    baseURL,
+		headers:    make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithAPIKey sets the key sent as bearer token on every call.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach the API.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithBaseURL overrides the API base URL, e.g. to target a proxy.
+func WithBaseURL(url string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHeader adds a header sent on every call, e.g. HTTP-Referer or X-Title.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent on every call.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, payload []byte) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	return req, nil
+}
+
+// call sends params as JSON to the given path and returns the raw response body.
+// Failed attempts are retried according to the client's retry policy. When ctx is
+// done before the call completes, its error is returned unwrapped.
+func (c *Client) call(ctx context.Context, method, path string, params any) ([]byte, error) {
+	_, body, err := c.send(ctx, method, path, params, false)
+	return body, err
+}
+
+// open is like call but returns the successful response with its body left unread,
+// for streamed responses. The caller must close the body.
+func (c *Client) open(ctx context.Context, method, path string, params any) (*http.Response, error) {
+	resp, _, err := c.send(ctx, method, path, params, true)
+	return resp, err
+}
+
+func (c *Client) send(ctx context.Context, method, path string, params any, stream bool) (*http.Response, []byte, error) {
+	var payload []byte
+	if params != nil {
+		var err error
+		if payload, err = json.Marshal(params); err != nil {
+			return nil, nil, fmt.Errorf("error marshaling request: %w", err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, body, err := c.do(ctx, method, path, payload, stream)
+		if err == nil {
+			return resp, body, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+
+		a := Attempt{Number: attempt, Err: err}
+		if resp != nil {
+			a.StatusCode = resp.StatusCode
+		}
+		if a.Retrying = c.retryPolicy.shouldRetry(method, path, attempt, resp, err); a.Retrying {
+			a.Delay = c.retryPolicy.delay(attempt, resp)
+		}
+		c.retryPolicy.observe(a)
+
+		if !a.Retrying {
+			return nil, nil, err
+		}
+		if err := sleep(ctx, a.Delay); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// do performs a single attempt. The response is returned along with API errors so
+// that callers can inspect its status and headers; its body is already consumed
+// unless stream is set and the call succeeded.
+func (c *Client) do(ctx context.Context, method, path string, payload []byte, stream bool) (*http.Response, []byte, error) {
+	req, err := c.newRequest(ctx, method, path, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	success := resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest
+	if success && stream {
+		return resp, nil, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if !success {
+		return resp, nil, newAPIError(resp.StatusCode, body)
+	}
+
+	return resp, body, nil
+}
+
+// callData is like call but decodes the data field of the JSON response, in which
+// most OpenRouter endpoints wrap their result.
+func callData[T any](ctx context.Context, c *Client, method, path string, params any) (T, error) {
+	var result struct {
+		Data T `json:"data"`
+	}
+
+	body, err := c.call(ctx, method, path, params)
+	if err != nil {
+		return result.Data, err
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return result.Data, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return result.Data, nil
+}
@@ -0,0 +1,102 @@
+package openrouter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GenerateContentWith(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer client-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "my-app/1.0", r.Header.Get("User-Agent"))
+		assert.Equal(t, "My App", r.Header.Get("X-Title"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"model":"google/gemini-2.5-flash-lite"`)
+
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIKey("client-key"),
+		WithHTTPClient(server.Client()),
+		WithBaseURL(server.URL+"/v1/"),
+		WithHeader("X-Title", "My App"),
+		WithUserAgent("my-app/1.0"),
+	)
+
+	res, err := ChatCompletion[testResponse]().
+		Use(ModelGemini2_5FlashLite).
+		AppendMessages(UserMessage{Content: []Content{TextContent{Text: "Test"}}}).
+		GenerateContentWith(client)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &testResponse{Answer: "ok"}, res)
+}
+
+func TestNewClient_Defaults(t *testing.T) {
+	client := NewClient()
+
+	assert.Equal(t, http.DefaultClient, client.httpClient)
+	assert.Equal(t, baseURL, client.baseURL)
+
+	req, err := client.newRequest(context.Background(), http.MethodGet, "/models", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, baseURL+"/models", req.URL.String())
+	assert.Empty(t, req.Header.Get("Authorization"))
+	assert.Empty(t, req.Header.Get("Content-Type"))
+}
+
+func TestClient_GenerateContentWithContext(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithHTTPClient(server.Client()), WithBaseURL(server.URL))
+	req := ChatCompletion[testResponse]().Use(ModelGemini2_5FlashLite)
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		res, err := req.GenerateContentWithContext(ctx, client)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, res)
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		res, err := req.GenerateContentWithContext(ctx, client)
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,115 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// MaxAudioSize is the largest audio accepted when building AudioContent from data.
+const MaxAudioSize = 25 << 20
+
+// AudioFormat is the encoding of audio content.
+type AudioFormat string
+
+const (
+	AudioFormatWAV AudioFormat = "wav"
+	AudioFormatMP3 AudioFormat = "mp3"
+)
+
+// AudioContent is audio sent along a message, for models accepting audio input.
+type AudioContent struct {
+	// Data is the base64 encoded audio.
+	Data   string      `json:"data"`
+	Format AudioFormat `json:"format"`
+}
+
+// NewAudioContentFromBytes builds audio content holding data. The format is
+// sniffed from data and must be WAV or MP3.
+func NewAudioContentFromBytes(data []byte) (AudioContent, error) {
+	if len(data) > MaxAudioSize {
+		return AudioContent{}, fmt.Errorf("%w: audio of %d bytes exceeds %d bytes", ErrContentTooLarge, len(data), MaxAudioSize)
+	}
+
+	format, ok := detectAudioFormat(data)
+	if !ok {
+		return AudioContent{}, fmt.Errorf("%w: audio is neither WAV nor MP3", ErrUnsupportedFormat)
+	}
+
+	return AudioContent{Data: base64.StdEncoding.EncodeToString(data), Format: format}, nil
+}
+
+// NewAudioContentFromReader is like NewAudioContentFromBytes but reads the audio from r.
+func NewAudioContentFromReader(r io.Reader) (AudioContent, error) {
+	data, err := readLimited(r, MaxAudioSize)
+	if err != nil {
+		return AudioContent{}, err
+	}
+
+	return NewAudioContentFromBytes(data)
+}
+
+// NewAudioContentFromFile is like NewAudioContentFromBytes but reads the audio from a file.
+func NewAudioContentFromFile(path string) (AudioContent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return AudioContent{}, fmt.Errorf("error opening audio: %w", err)
+	}
+	defer f.Close()
+
+	return NewAudioContentFromReader(f)
+}
+
+func (c AudioContent) validate() error {
+	switch c.Format {
+	case AudioFormatWAV, AudioFormatMP3:
+		return nil
+	default:
+		return fmt.Errorf("%w: audio format %q", ErrUnsupportedFormat, c.Format)
+	}
+}
+
+func detectAudioFormat(data []byte) (AudioFormat, bool) {
+	switch {
+	case len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WAVE")):
+		return AudioFormatWAV, true
+	case bytes.HasPrefix(data, []byte("ID3")):
+		return AudioFormatMP3, true
+	case len(data) >= 2 && data[0] == 0xff && data[1]&0xe0 == 0xe0 && (data[1]>>1)&0x3 != 0:
+		// MPEG frame sync, for MP3 files without ID3 tag. A zero layer is AAC ADTS.
+		return AudioFormatMP3, true
+	}
+
+	return "", false
+}
+
+func (c AudioContent) Type() string {
+	return "input_audio"
+}
+
+func (c AudioContent) MarshalJSON() ([]byte, error) {
+	type Alias AudioContent
+	return json.Marshal(&struct {
+		Type       string `json:"type"`
+		InputAudio Alias  `json:"input_audio"`
+	}{
+		Type:       c.Type(),
+		InputAudio: (Alias)(c),
+	})
+}
+
+func (c *AudioContent) UnmarshalJSON(data []byte) error {
+	type Alias AudioContent
+	var aux struct {
+		InputAudio Alias `json:"input_audio"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*c = AudioContent(aux.InputAudio)
+	return nil
+}
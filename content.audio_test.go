@@ -0,0 +1,103 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	wavAudio = []byte("RIFF\x24\x00\x00\x00WAVEfmt ")
+	mp3Audio = []byte("ID3\x04\x00\x00\x00\x00\x00\x00")
+)
+
+func TestNewAudioContentFromBytes(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		wantFormat AudioFormat
+		wantErr    error
+	}{
+		{"wav", wavAudio, AudioFormatWAV, nil},
+		{"mp3 with ID3 tag", mp3Audio, AudioFormatMP3, nil},
+		{"mp3 frame", []byte("\xff\xfb\x90\x64\x00"), AudioFormatMP3, nil},
+		{"aac adts", []byte("\xff\xf1\x50\x80"), "", ErrUnsupportedFormat},
+		{"riff without wave", webpImage, "", ErrUnsupportedFormat},
+		{"ogg", []byte("OggS\x00\x02"), "", ErrUnsupportedFormat},
+		{"empty", nil, "", ErrUnsupportedFormat},
+		{"too large", append(bytes.Clone(wavAudio), make([]byte, MaxAudioSize)...), "", ErrContentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAudioContentFromBytes(tt.data)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, AudioContent{Data: base64.StdEncoding.EncodeToString(tt.data), Format: tt.wantFormat}, got)
+		})
+	}
+}
+
+func TestNewAudioContentFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "call.mp3")
+	assert.NoError(t, os.WriteFile(path, mp3Audio, 0o600))
+
+	got, err := NewAudioContentFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, AudioContent{Data: base64.StdEncoding.EncodeToString(mp3Audio), Format: AudioFormatMP3}, got)
+
+	_, err = NewAudioContentFromReader(bytes.NewReader(make([]byte, MaxAudioSize+1)))
+	assert.ErrorIs(t, err, ErrContentTooLarge)
+
+	_, err = NewAudioContentFromFile(filepath.Join(t.TempDir(), "missing.wav"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAudioContent_JSON(t *testing.T) {
+	content := AudioContent{Data: "UklGRg==", Format: AudioFormatWAV}
+
+	data, err := json.Marshal(content)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"input_audio","input_audio":{"data":"UklGRg==","format":"wav"}}`, string(data))
+
+	var msg UserMessage
+	assert.NoError(t, json.Unmarshal([]byte(`{"role":"user","content":[`+string(data)+`]}`), &msg))
+	assert.Equal(t, []Content{content}, msg.Content)
+}
+
+func TestChatCompletionRequest_Validate_AudioFormat(t *testing.T) {
+	tests := []struct {
+		format  AudioFormat
+		wantErr bool
+	}{
+		{AudioFormatWAV, false},
+		{AudioFormatMP3, false},
+		{"ogg", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			err := ChatCompletion[struct{}]().
+				AppendMessages(UserMessage{Content: []Content{AudioContent{Data: "UklGRg==", Format: tt.format}}}).
+				Validate()
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.ErrorIs(t, err, ErrUnsupportedFormat)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxFileSize is the largest file accepted when building FileContent from data.
+const MaxFileSize = 50 << 20
+
+// FileContent is a file, such as a PDF, sent along a message. Data is either a
+// data URL or the URL of a publicly reachable file.
+type FileContent struct {
+	Filename string `json:"filename"`
+	Data     string `json:"file_data"`
+}
+
+// NewFileContentFromBytes builds file content holding data as a data URL. The
+// MIME type is sniffed from data, falling back to the extension of filename.
+func NewFileContentFromBytes(filename string, data []byte) (FileContent, error) {
+	if filename == "" {
+		return FileContent{}, errors.New("file name cannot be empty")
+	}
+	if len(data) > MaxFileSize {
+		return FileContent{}, fmt.Errorf("%w: file of %d bytes exceeds %d bytes", ErrContentTooLarge, len(data), MaxFileSize)
+	}
+
+	return FileContent{Filename: filename, Data: dataURL(fileMIMEType(filename, data), data)}, nil
+}
+
+// NewFileContentFromReader is like NewFileContentFromBytes but reads the file from r.
+func NewFileContentFromReader(filename string, r io.Reader) (FileContent, error) {
+	data, err := readLimited(r, MaxFileSize)
+	if err != nil {
+		return FileContent{}, err
+	}
+
+	return NewFileContentFromBytes(filename, data)
+}
+
+// NewFileContentFromFile is like NewFileContentFromBytes but reads the file at path,
+// named after its base name.
+func NewFileContentFromFile(path string) (FileContent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FileContent{}, fmt.Errorf("error opening file: %w", err)
+	}
+	defer f.Close()
+
+	return NewFileContentFromReader(filepath.Base(path), f)
+}
+
+func fileMIMEType(filename string, data []byte) string {
+	sniffed := http.DetectContentType(data)
+	if sniffed != "application/octet-stream" && !strings.HasPrefix(sniffed, "text/plain") {
+		return sniffed
+	}
+
+	if byExtension := mime.TypeByExtension(filepath.Ext(filename)); byExtension != "" {
+		return byExtension
+	}
+
+	return sniffed
+}
+
+func (c FileContent) Type() string {
+	return "file"
+}
+
+func (c FileContent) MarshalJSON() ([]byte, error) {
+	type Alias FileContent
+	return json.Marshal(&struct {
+		Type string `json:"type"`
+		File Alias  `json:"file"`
+	}{
+		Type: c.Type(),
+		File: (Alias)(c),
+	})
+}
+
+func (c *FileContent) UnmarshalJSON(data []byte) error {
+	type Alias FileContent
+	var aux struct {
+		File Alias `json:"file"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*c = FileContent(aux.File)
+	return nil
+}
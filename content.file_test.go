@@ -0,0 +1,143 @@
+package openrouter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pdfFile = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+func TestNewFileContentFromBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     []byte
+		wantType string
+		wantErr  error
+	}{
+		{"pdf", "invoice.pdf", pdfFile, "application/pdf", nil},
+		{"sniffed over extension", "invoice.txt", pdfFile, "application/pdf", nil},
+		{"csv from extension", "report.csv", []byte("a,b\n1,2\n"), "text/csv; charset=utf-8", nil},
+		{"plain text", "notes", []byte("hello world"), "text/plain; charset=utf-8", nil},
+		{"too large", "invoice.pdf", append(bytes.Clone(pdfFile), make([]byte, MaxFileSize)...), "", ErrContentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFileContentFromBytes(tt.filename, tt.data)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.filename, got.Filename)
+			assert.Equal(t, "data:"+tt.wantType+";base64,"+base64.StdEncoding.EncodeToString(tt.data), got.Data)
+		})
+	}
+
+	t.Run("empty file name", func(t *testing.T) {
+		_, err := NewFileContentFromBytes("", pdfFile)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewFileContentFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contract.pdf")
+	assert.NoError(t, os.WriteFile(path, pdfFile, 0o600))
+
+	got, err := NewFileContentFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "contract.pdf", got.Filename)
+	assert.Equal(t, "data:application/pdf;base64,"+base64.StdEncoding.EncodeToString(pdfFile), got.Data)
+
+	_, err = NewFileContentFromReader("contract.pdf", bytes.NewReader(make([]byte, MaxFileSize+1)))
+	assert.ErrorIs(t, err, ErrContentTooLarge)
+
+	_, err = NewFileContentFromFile(filepath.Join(t.TempDir(), "missing.pdf"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileContent_JSON(t *testing.T) {
+	content := FileContent{Filename: "invoice.pdf", Data: "https://example.com/invoice.pdf"}
+
+	data, err := json.Marshal(content)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"file","file":{"filename":"invoice.pdf","file_data":"https://example.com/invoice.pdf"}}`, string(data))
+
+	var msg UserMessage
+	assert.NoError(t, json.Unmarshal([]byte(`{"role":"user","content":[`+string(data)+`]}`), &msg))
+	assert.Equal(t, []Content{content}, msg.Content)
+}
+
+func TestChatCompletionRequest_WithFileParser(t *testing.T) {
+	annotations := `[{"type":"file","file":{"hash":"abc","name":"invoice.pdf","content":[{"type":"text","text":"Total: 42"}]}}]`
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{}","annotations":` + annotations + `}}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+
+	conv := NewConversation()
+	res, err := ChatCompletion[struct{}]().
+		Use(ModelGemini3Pro).
+		WithFileParser(PDFEngineMistralOCR).
+		InConversation(conv).
+		AppendMessages(UserMessage{Content: []Content{FileContent{Filename: "invoice.pdf", Data: "https://example.com/invoice.pdf"}}}).
+		GenerateResponse(context.Background(), client)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"file-parser","pdf":{"engine":"mistral-ocr"}}]`, string(field(t, bodies[0], "plugins")))
+	assert.Equal(t, []Annotation{{
+		Type: "file",
+		File: &FileAnnotation{Hash: "abc", Name: "invoice.pdf", Content: []map[string]any{{"type": "text", "text": "Total: 42"}}},
+	}}, res.Annotations)
+
+	_, err = ChatCompletion[struct{}]().
+		Use(ModelGemini3Pro).
+		InConversation(conv).
+		AppendMessages(UserMessage{Content: []Content{TextContent{Text: "And the due date?"}}}).
+		GenerateResponse(context.Background(), client)
+
+	assert.NoError(t, err)
+	var messages []map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(field(t, bodies[1], "messages"), &messages))
+	assert.Len(t, messages, 3)
+	assert.JSONEq(t, annotations, string(messages[1]["annotations"]))
+}
+
+func TestChatCompletionRequest_WithFileParser_Replaces(t *testing.T) {
+	req := ChatCompletion[struct{}]().WithFileParser(PDFEnginePDFText).WithFileParser(PDFEngineNative)
+
+	data, err := json.Marshal(req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"file-parser","pdf":{"engine":"native"}}]`, string(field(t, string(data), "plugins")))
+	assert.NoError(t, req.Validate())
+
+	err = ChatCompletion[struct{}]().WithFileParser("bogus").Validate()
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	assert.ErrorContains(t, err, "unsupported PDF engine: bogus")
+}
+
+func field(t *testing.T, body, name string) json.RawMessage {
+	t.Helper()
+
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal([]byte(body), &fields))
+	return fields[name]
+}
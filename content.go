@@ -1,6 +1,87 @@
 package openrouter
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrContentTooLarge is returned when building content from data exceeding the size limit.
+	ErrContentTooLarge = errors.New("content too large")
+	// ErrUnsupportedFormat is returned when building content from data in an unsupported format.
+	ErrUnsupportedFormat = errors.New("unsupported format")
+)
+
 // Content is the interface for different content types.
 type Content interface {
 	Type() string
 }
+
+// unmarshalContent decodes a content part into the type matching its type.
+func unmarshalContent(data []byte) (Content, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	switch header.Type {
+	case "text":
+		var c TextContent
+		err := json.Unmarshal(data, &c)
+		return c, err
+	case "image_url":
+		var c ImageContent
+		err := json.Unmarshal(data, &c)
+		return c, err
+	case "file":
+		var c FileContent
+		err := json.Unmarshal(data, &c)
+		return c, err
+	case "input_audio":
+		var c AudioContent
+		err := json.Unmarshal(data, &c)
+		return c, err
+	}
+
+	return nil, fmt.Errorf("unsupported content type: %q", header.Type)
+}
+
+// readLimited reads r entirely, failing when it holds more than limit bytes.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading content: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrContentTooLarge, limit)
+	}
+
+	return data, nil
+}
+
+// dataURL encodes data as a base64 data URL.
+func dataURL(mimeType string, data []byte) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// parseDataURL decodes a base64 data URL into its MIME type and data.
+func parseDataURL(url string) (string, []byte, error) {
+	header, payload, found := strings.Cut(url, ",")
+	mimeType, isBase64 := strings.CutSuffix(strings.TrimPrefix(header, "data:"), ";base64")
+	if !found || !strings.HasPrefix(header, "data:") || !isBase64 {
+		return "", nil, fmt.Errorf("%w: not a base64 data URL", ErrUnsupportedFormat)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("error decoding data URL: %w", err)
+	}
+
+	return mimeType, data, nil
+}
@@ -1,12 +1,60 @@
 package openrouter
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+)
+
+// MaxImageSize is the largest image accepted when building ImageContent from data.
+const MaxImageSize = 20 << 20
+
+var supportedImageTypes = []string{"image/png", "image/jpeg", "image/webp", "image/gif"}
 
 type ImageContent struct {
 	URL    string `json:"url"`
 	Detail string `json:"detail,omitempty"`
 }
 
+// NewImageContentFromBytes builds image content holding data as a data URL. The
+// format is sniffed from data and must be PNG, JPEG, WebP or GIF.
+func NewImageContentFromBytes(data []byte) (ImageContent, error) {
+	if len(data) > MaxImageSize {
+		return ImageContent{}, fmt.Errorf("%w: image of %d bytes exceeds %d bytes", ErrContentTooLarge, len(data), MaxImageSize)
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !slices.Contains(supportedImageTypes, mimeType) {
+		return ImageContent{}, fmt.Errorf("%w: image type %s", ErrUnsupportedFormat, mimeType)
+	}
+
+	return ImageContent{URL: dataURL(mimeType, data)}, nil
+}
+
+// NewImageContentFromReader is like NewImageContentFromBytes but reads the image from r.
+func NewImageContentFromReader(r io.Reader) (ImageContent, error) {
+	data, err := readLimited(r, MaxImageSize)
+	if err != nil {
+		return ImageContent{}, err
+	}
+
+	return NewImageContentFromBytes(data)
+}
+
+// NewImageContentFromFile is like NewImageContentFromBytes but reads the image from a file.
+func NewImageContentFromFile(path string) (ImageContent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ImageContent{}, fmt.Errorf("error opening image: %w", err)
+	}
+	defer f.Close()
+
+	return NewImageContentFromReader(f)
+}
+
 func (c ImageContent) Type() string {
 	return "image_url"
 }
@@ -21,3 +69,16 @@ func (c ImageContent) MarshalJSON() ([]byte, error) {
 		ImageURL: (Alias)(c),
 	})
 }
+
+func (c *ImageContent) UnmarshalJSON(data []byte) error {
+	type Alias ImageContent
+	var aux struct {
+		ImageURL Alias `json:"image_url"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*c = ImageContent(aux.ImageURL)
+	return nil
+}
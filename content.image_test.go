@@ -0,0 +1,70 @@
+package openrouter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	pngImage  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpegImage = []byte("\xff\xd8\xff\xe0\x00\x10JFIF")
+	gifImage  = []byte("GIF89a\x01\x00\x01\x00")
+	webpImage = []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+)
+
+func TestNewImageContentFromBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		wantType string
+		wantErr  error
+	}{
+		{"png", pngImage, "image/png", nil},
+		{"jpeg", jpegImage, "image/jpeg", nil},
+		{"gif", gifImage, "image/gif", nil},
+		{"webp", webpImage, "image/webp", nil},
+		{"text", []byte("hello world"), "", ErrUnsupportedFormat},
+		{"pdf", []byte("%PDF-1.7"), "", ErrUnsupportedFormat},
+		{"too large", append(bytes.Clone(pngImage), make([]byte, MaxImageSize)...), "", ErrContentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewImageContentFromBytes(tt.data)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "data:"+tt.wantType+";base64,"+base64.StdEncoding.EncodeToString(tt.data), got.URL)
+		})
+	}
+}
+
+func TestNewImageContentFromReader(t *testing.T) {
+	got, err := NewImageContentFromReader(bytes.NewReader(pngImage))
+	assert.NoError(t, err)
+	assert.Equal(t, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(pngImage), got.URL)
+
+	_, err = NewImageContentFromReader(bytes.NewReader(make([]byte, MaxImageSize+1)))
+	assert.ErrorIs(t, err, ErrContentTooLarge)
+}
+
+func TestNewImageContentFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "image.gif")
+	assert.NoError(t, os.WriteFile(path, gifImage, 0o600))
+
+	got, err := NewImageContentFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "data:image/gif;base64,"+base64.StdEncoding.EncodeToString(gifImage), got.URL)
+
+	_, err = NewImageContentFromFile(filepath.Join(t.TempDir(), "missing.png"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
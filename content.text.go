@@ -4,6 +4,29 @@ import "encoding/json"
 
 type TextContent struct {
 	Text string `json:"text"`
+	// CacheControl marks the end of a prompt prefix to cache, for providers that
+	// need explicit breakpoints.
+	CacheControl *CacheControl `json:"cache_control,omitempty"`
+}
+
+// CacheTTL is how long a cached prompt prefix is kept.
+type CacheTTL string
+
+const (
+	CacheTTL5Minutes CacheTTL = "5m"
+	CacheTTL1Hour    CacheTTL = "1h"
+)
+
+// CacheControl https://openrouter.ai/docs/features/prompt-caching
+type CacheControl struct {
+	Type string   `json:"type"`
+	TTL  CacheTTL `json:"ttl,omitempty"`
+}
+
+// EphemeralCache returns an ephemeral cache breakpoint. An empty ttl keeps the
+// provider default, usually five minutes.
+func EphemeralCache(ttl CacheTTL) *CacheControl {
+	return &CacheControl{Type: "ephemeral", TTL: ttl}
 }
 
 func (c TextContent) Type() string {
@@ -0,0 +1,87 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Conversation owns the message history of a multi-turn exchange. Requests bound
+// to it with InConversation send the whole history and, once answered, append
+// their own messages and the assistant reply to it.
+//
+// A Conversation serializes to the JSON array of its messages, so that it can be
+// stored and restored later.
+type Conversation struct {
+	mu       sync.RWMutex
+	messages []Message
+}
+
+// NewConversation creates a conversation starting with the given messages.
+func NewConversation(messages ...Message) *Conversation {
+	return &Conversation{messages: slices.Clone(messages)}
+}
+
+// Append adds messages at the end of the conversation.
+func (c *Conversation) Append(messages ...Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = append(c.messages, messages...)
+}
+
+// Messages returns a copy of the history.
+func (c *Conversation) Messages() []Message {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.messages)
+}
+
+// Len returns the number of messages in the history.
+func (c *Conversation) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.messages)
+}
+
+// Fork returns an independent copy of the conversation, to explore another branch
+// without altering this one.
+func (c *Conversation) Fork() *Conversation {
+	return NewConversation(c.Messages()...)
+}
+
+// ForkAt is like Fork but only keeps the first n messages, to branch off an
+// earlier turn.
+func (c *Conversation) ForkAt(n int) *Conversation {
+	messages := c.Messages()
+	return NewConversation(messages[:min(max(n, 0), len(messages))]...)
+}
+
+func (c *Conversation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Messages())
+}
+
+func (c *Conversation) UnmarshalJSON(data []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	messages := make([]Message, 0, len(raws))
+	for i, raw := range raws {
+		m, err := unmarshalMessage(raw)
+		if err != nil {
+			return fmt.Errorf("error unmarshaling message %d: %w", i, err)
+		}
+		messages = append(messages, m)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = messages
+	return nil
+}
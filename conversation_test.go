@@ -0,0 +1,139 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversation(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	var received [][]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body struct {
+			Messages []any `json:"messages"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &body))
+		received = append(received, body.Messages)
+
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"answer\":\"Paris\"}"}}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+
+	system := SystemMessage{Content: "You are a geography expert"}
+	question := UserMessage{Content: []Content{TextContent{Text: "Capital of France?"}}}
+	followUp := UserMessage{Content: []Content{TextContent{Text: "Are you sure?"}}}
+	reply := AssistantMessage{Content: `{"answer":"Paris"}`}
+
+	conv := NewConversation(system)
+
+	_, err := ChatCompletion[testResponse]().InConversation(conv).AppendMessages(question).GenerateContentWith(client)
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{system, question, reply}, conv.Messages())
+
+	branch := conv.Fork()
+
+	_, err = ChatCompletion[testResponse]().InConversation(conv).AppendMessages(followUp).GenerateContentWith(client)
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{system, question, reply, followUp, reply}, conv.Messages())
+	assert.Equal(t, 3, branch.Len(), "forks must not be affected")
+	assert.Equal(t, []Message{system, question}, conv.ForkAt(2).Messages())
+
+	if assert.Len(t, received, 2) {
+		assert.Len(t, received[0], 2)
+		assert.Len(t, received[1], 4)
+	}
+
+	t.Run("failed requests are not recorded", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		conv := NewConversation(system)
+		_, err := ChatCompletion[testResponse]().InConversation(conv).AppendMessages(question).
+			GenerateContentWith(NewClient(WithBaseURL(failing.URL)))
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, conv.Len())
+	})
+}
+
+func TestConversation_JSON(t *testing.T) {
+	conv := NewConversation(
+		SystemMessage{Content: "System prompt"},
+		DeveloperMessage{Content: "Developer prompt"},
+		UserMessage{Name: "alice", Content: []Content{
+			TextContent{Text: "What is in this image?"},
+			ImageContent{URL: "https://example.com/image.jpg", Detail: "high"},
+		}},
+		AssistantMessage{ToolCalls: []ToolCall{{ID: "call_1", Type: "function", Function: FunctionCall{Name: "describe", Arguments: "{}"}}}},
+		ToolMessage{ToolCallID: "call_1", Content: "a cat"},
+		AssistantMessage{Content: `{"answer":"a cat"}`},
+	)
+
+	data, err := json.Marshal(conv)
+	assert.NoError(t, err)
+
+	var restored Conversation
+	assert.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, conv.Messages(), restored.Messages())
+
+	t.Run("string user content", func(t *testing.T) {
+		var conv Conversation
+		assert.NoError(t, json.Unmarshal([]byte(`[{"role":"user","content":"Hello"}]`), &conv))
+		assert.Equal(t, []Message{UserMessage{Content: []Content{TextContent{Text: "Hello"}}}}, conv.Messages())
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		var conv Conversation
+		err := json.Unmarshal([]byte(`[{"role":"narrator","content":"Once upon a time"}]`), &conv)
+		assert.ErrorContains(t, err, `unsupported message role: "narrator"`)
+	})
+
+	t.Run("unknown content type", func(t *testing.T) {
+		var conv Conversation
+		err := json.Unmarshal([]byte(`[{"role":"user","content":[{"type":"video"}]}]`), &conv)
+		assert.ErrorContains(t, err, `unsupported content type: "video"`)
+	})
+}
+
+func TestAgent_Conversation(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"answer\":\"5 degrees\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	system := SystemMessage{Content: "You are a weather assistant"}
+	conv := NewConversation(system)
+	req := ChatCompletion[testResponse]().
+		InConversation(conv).
+		AppendMessages(UserMessage{Content: []Content{TextContent{Text: "Weather in Paris?"}}}).
+		WithTools(weatherTool())
+
+	res, err := NewAgent(req).Run(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Transcript, 5)
+	assert.Equal(t, res.Transcript, conv.Messages())
+}
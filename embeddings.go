@@ -0,0 +1,194 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const (
+	defaultEmbeddingsBatchSize   = 100
+	defaultEmbeddingsConcurrency = 4
+)
+
+// EmbeddingsRequest builds a request embedding many inputs. Inputs are split into
+// batches sent concurrently, and the resulting vectors are returned in input order.
+type EmbeddingsRequest struct {
+	model       Model
+	inputs      []string
+	batchSize   int
+	concurrency int
+}
+
+func Embeddings() *EmbeddingsRequest {
+	return &EmbeddingsRequest{
+		batchSize:   defaultEmbeddingsBatchSize,
+		concurrency: defaultEmbeddingsConcurrency,
+	}
+}
+
+func (r *EmbeddingsRequest) Use(model Model) *EmbeddingsRequest {
+	r.model = model
+	return r
+}
+
+func (r *EmbeddingsRequest) AppendInputs(inputs ...string) *EmbeddingsRequest {
+	r.inputs = append(r.inputs, inputs...)
+	return r
+}
+
+// WithBatchSize sets the maximum number of inputs sent in a single call. Defaults to 100.
+func (r *EmbeddingsRequest) WithBatchSize(size int) *EmbeddingsRequest {
+	r.batchSize = size
+	return r
+}
+
+// WithConcurrency sets the maximum number of batches sent at once. Defaults to 4.
+func (r *EmbeddingsRequest) WithConcurrency(concurrency int) *EmbeddingsRequest {
+	r.concurrency = concurrency
+	return r
+}
+
+// EmbeddingsResponse holds one vector per input, in input order.
+type EmbeddingsResponse struct {
+	Model      Model
+	Embeddings [][]float32
+	// Usage is summed over all batches.
+	Usage Usage
+}
+
+type embeddingsPayload struct {
+	Model Model    `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResult struct {
+	Model Model `json:"model"`
+	Data  []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Usage *Usage `json:"usage"`
+}
+
+// Generate embeds the inputs. The first failing batch cancels the others.
+func (r EmbeddingsRequest) Generate(ctx context.Context, client *Client) (*EmbeddingsResponse, error) {
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	res := &EmbeddingsResponse{Model: r.model, Embeddings: make([][]float32, len(r.inputs))}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, r.concurrency)
+	)
+
+	for start := 0; start < len(r.inputs); start += r.batchSize {
+		end := min(start+r.batchSize, len(r.inputs))
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := r.embed(ctx, client, start, end)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+
+			for _, d := range result.Data {
+				res.Embeddings[start+d.Index] = d.Embedding
+			}
+			if result.Model != "" {
+				res.Model = result.Model
+			}
+			if result.Usage != nil {
+				res.Usage.PromptTokens += result.Usage.PromptTokens
+				res.Usage.CompletionTokens += result.Usage.CompletionTokens
+				res.Usage.TotalTokens += result.Usage.TotalTokens
+				res.Usage.Cost += result.Usage.Cost
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// embed sends the inputs between start and end as a single batch.
+func (r EmbeddingsRequest) embed(ctx context.Context, client *Client, start, end int) (*embeddingsResult, error) {
+	body, err := client.call(ctx, http.MethodPost, "/embeddings", embeddingsPayload{
+		Model: r.model,
+		Input: r.inputs[start:end],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result embeddingsResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	if len(result.Data) != end-start {
+		return nil, fmt.Errorf("unexpected error: got %d embeddings for %d inputs", len(result.Data), end-start)
+	}
+	seen := make([]bool, end-start)
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= end-start {
+			return nil, fmt.Errorf("unexpected error: embedding index %d out of range", d.Index)
+		}
+		if seen[d.Index] {
+			return nil, fmt.Errorf("unexpected error: duplicate embedding index %d", d.Index)
+		}
+		seen[d.Index] = true
+	}
+
+	return &result, nil
+}
+
+func (r EmbeddingsRequest) validate() error {
+	if len(r.inputs) == 0 {
+		return errors.New("no inputs to embed")
+	}
+	if r.batchSize < 1 {
+		return fmt.Errorf("batch size must be at least 1, got %d", r.batchSize)
+	}
+	if r.concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", r.concurrency)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeddingsRequest_Generate(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/embeddings", r.URL.Path)
+		calls.Add(1)
+
+		data, _ := io.ReadAll(r.Body)
+		var body struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, "openai/text-embedding-3-small", body.Model)
+		assert.LessOrEqual(t, len(body.Input), 2)
+
+		// Answer in reverse order to check that vectors are placed by index.
+		items := make([]string, 0, len(body.Input))
+		for i := len(body.Input) - 1; i >= 0; i-- {
+			items = append(items, fmt.Sprintf(`{"index":%d,"embedding":[%d,0.5]}`, i, len(body.Input[i])))
+		}
+		_, _ = fmt.Fprintf(w, `{"model":"openai/text-embedding-3-small","data":[%s],"usage":{"prompt_tokens":%d,"total_tokens":%d,"cost":0.5}}`,
+			strings.Join(items, ","), len(body.Input), len(body.Input))
+	}))
+	defer server.Close()
+
+	res, err := Embeddings().
+		Use("openai/text-embedding-3-small").
+		AppendInputs("a", "bb", "ccc", "dddd", "eeeee").
+		WithBatchSize(2).
+		WithConcurrency(2).
+		Generate(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Model("openai/text-embedding-3-small"), res.Model)
+	assert.Equal(t, [][]float32{{1, 0.5}, {2, 0.5}, {3, 0.5}, {4, 0.5}, {5, 0.5}}, res.Embeddings)
+	assert.Equal(t, Usage{PromptTokens: 5, TotalTokens: 5, Cost: 1.5}, res.Usage)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestEmbeddingsRequest_Generate_Errors(t *testing.T) {
+	t.Run("api error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"error":{"code":402,"message":"Insufficient credits"}}`))
+		}))
+		defer server.Close()
+
+		res, err := Embeddings().Use("openai/text-embedding-3-small").AppendInputs("a", "b", "c").WithBatchSize(1).
+			Generate(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.ErrorIs(t, err, ErrInsufficientCredits)
+		assert.Nil(t, res)
+	})
+
+	t.Run("missing embeddings", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":[{"index":0,"embedding":[1]}]}`))
+		}))
+		defer server.Close()
+
+		_, err := Embeddings().AppendInputs("a", "b").Generate(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.EqualError(t, err, "unexpected error: got 1 embeddings for 2 inputs")
+	})
+
+	t.Run("duplicate embeddings", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":[{"index":0,"embedding":[1]},{"index":0,"embedding":[2]}]}`))
+		}))
+		defer server.Close()
+
+		_, err := Embeddings().AppendInputs("a", "b").Generate(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.EqualError(t, err, "unexpected error: duplicate embedding index 0")
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		client := NewClient()
+		ctx := context.Background()
+
+		_, err := Embeddings().Generate(ctx, client)
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+		assert.ErrorContains(t, err, "no inputs to embed")
+
+		_, err = Embeddings().AppendInputs("a").WithBatchSize(0).Generate(ctx, client)
+		assert.ErrorContains(t, err, "batch size must be at least 1, got 0")
+
+		_, err = Embeddings().AppendInputs("a").WithConcurrency(0).Generate(ctx, client)
+		assert.ErrorContains(t, err, "concurrency must be at least 1, got 0")
+	})
+}
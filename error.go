@@ -1,10 +1,157 @@
 package openrouter
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrInvalidRequest matches API errors caused by bad request parameters, as well
+	// as requests rejected by local validation before being sent.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrUnauthorized matches API errors caused by a missing, invalid or disabled API key.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInsufficientCredits matches API errors caused by an account running out of credits.
+	ErrInsufficientCredits = errors.New("insufficient credits")
+	// ErrModeration matches API errors caused by the input being flagged by moderation.
+	ErrModeration = errors.New("flagged by moderation")
+	// ErrRateLimited matches API errors caused by rate limiting.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrProviderUnavailable matches API errors caused by the chosen model or providers being down.
+	ErrProviderUnavailable = errors.New("provider unavailable")
+)
+
+// maxErrorBodyLength bounds how much of a non-JSON error body ends up in APIError.Message.
+const maxErrorBodyLength = 512
+
+// ErrorMetadata holds the extra details OpenRouter attaches to some errors.
+type ErrorMetadata struct {
+	// ProviderName is the upstream provider that failed or flagged the request.
+	ProviderName string `json:"provider_name,omitempty"`
+	// Raw is the error returned by the upstream provider, as is.
+	Raw json.RawMessage `json:"raw,omitempty"`
+	// Reasons lists why the input was flagged by moderation.
+	Reasons []string `json:"reasons,omitempty"`
+	// FlaggedInput is the part of the input flagged by moderation.
+	FlaggedInput string `json:"flagged_input,omitempty"`
+	// ModelSlug is the model the request was routed to.
+	ModelSlug string `json:"model_slug,omitempty"`
+}
+
+// APIError is returned when OpenRouter answers with an error status.
+// Use errors.As to inspect it, or errors.Is with the Err* sentinels to branch on its cause.
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+	// Code is the numeric error code, usually the HTTP status.
+	Code int `json:"code"`
+	// CodeName is set instead of Code by errors sent with a string code, such as
+	// "server_error" for errors raised in the middle of a stream.
+	CodeName string `json:"-"`
+	Message  string `json:"message"`
+	Param    string `json:"param,omitempty"`
+	Type     string `json:"type,omitempty"`
+	// Metadata is only set by OpenRouter for provider and moderation errors.
+	Metadata *ErrorMetadata `json:"metadata,omitempty"`
+	// Body is the raw response body.
+	Body []byte `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d - %s", e.StatusCode, e.Message)
+}
+
+// UnmarshalJSON accepts both numeric and string error codes.
+func (e *APIError) UnmarshalJSON(data []byte) error {
+	type Alias APIError
+	aux := struct {
+		*Alias
+		Code json.RawMessage `json:"code"`
+	}{Alias: (*Alias)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.Code) == 0 || string(aux.Code) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(aux.Code, &e.Code); err == nil {
+		return nil
+	}
+
+	return json.Unmarshal(aux.Code, &e.CodeName)
+}
+
+// Is reports whether the error matches one of the Err* sentinels.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrInsufficientCredits:
+		return e.StatusCode == http.StatusPaymentRequired
+	case ErrModeration:
+		return e.StatusCode == http.StatusForbidden || (e.Metadata != nil && len(e.Metadata.Reasons) > 0)
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrProviderUnavailable:
+		return e.StatusCode == http.StatusBadGateway || e.StatusCode == http.StatusServiceUnavailable
+	}
+
+	return false
+}
+
+// codeNameStatuses maps the string codes of errors raised in the middle of a
+// stream to the HTTP status they would have been sent with, so that they match
+// the same sentinels. Mid-stream server errors come from the provider.
+var codeNameStatuses = map[string]int{
+	"invalid_request_error": http.StatusBadRequest,
+	"rate_limit_exceeded":   http.StatusTooManyRequests,
+	"server_error":          http.StatusBadGateway,
+}
+
+// streamStatus returns the HTTP status matching an error sent in a stream chunk.
+func (e *APIError) streamStatus() int {
+	if e.Code != 0 {
+		return e.Code
+	}
+	if status, ok := codeNameStatuses[e.CodeName]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
 type apiError struct {
-	Error struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-		Param   string `json:"param"`
-		Type    string `json:"type"`
-	} `json:"error"`
+	Error *APIError `json:"error"`
+}
+
+// newAPIError builds the error matching an error response. Bodies that are not
+// OpenRouter JSON errors, such as HTML pages served by a proxy, are kept as the message.
+func newAPIError(statusCode int, body []byte) *APIError {
+	var respErr apiError
+	if err := json.Unmarshal(body, &respErr); err == nil && respErr.Error != nil && respErr.Error.Message != "" {
+		respErr.Error.StatusCode = statusCode
+		respErr.Error.Body = body
+		return respErr.Error
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxErrorBodyLength {
+		message = message[:maxErrorBodyLength] + "..."
+	}
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+
+	return &APIError{
+		StatusCode: statusCode,
+		Code:       statusCode,
+		Message:    message,
+		Body:       body,
+	}
 }
@@ -0,0 +1,120 @@
+package openrouter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIError(t *testing.T) {
+	t.Run("json body", func(t *testing.T) {
+		err := newAPIError(http.StatusForbidden, []byte(`{
+			"error": {
+				"code": 403,
+				"message": "Input was flagged",
+				"metadata": {
+					"reasons": ["harassment"],
+					"flagged_input": "some input",
+					"provider_name": "OpenAI",
+					"model_slug": "openai/gpt-5.2"
+				}
+			}
+		}`))
+
+		assert.Equal(t, http.StatusForbidden, err.StatusCode)
+		assert.Equal(t, 403, err.Code)
+		assert.Equal(t, "Input was flagged", err.Message)
+		assert.Equal(t, "API error: 403 - Input was flagged", err.Error())
+		if assert.NotNil(t, err.Metadata) {
+			assert.Equal(t, []string{"harassment"}, err.Metadata.Reasons)
+			assert.Equal(t, "some input", err.Metadata.FlaggedInput)
+			assert.Equal(t, "OpenAI", err.Metadata.ProviderName)
+			assert.Equal(t, "openai/gpt-5.2", err.Metadata.ModelSlug)
+		}
+	})
+
+	t.Run("provider raw error", func(t *testing.T) {
+		err := newAPIError(http.StatusBadGateway, []byte(`{"error":{"code":502,"message":"Provider returned error","metadata":{"provider_name":"Anthropic","raw":{"type":"overloaded_error"}}}}`))
+
+		assert.Equal(t, "Anthropic", err.Metadata.ProviderName)
+		assert.JSONEq(t, `{"type":"overloaded_error"}`, string(err.Metadata.Raw))
+	})
+
+	t.Run("string code", func(t *testing.T) {
+		err := newAPIError(http.StatusInternalServerError, []byte(`{"error":{"code":"server_error","message":"Internal error","metadata":{"provider_name":"Google"}}}`))
+
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Zero(t, err.Code)
+		assert.Equal(t, "server_error", err.CodeName)
+		assert.Equal(t, "API error: 500 - Internal error", err.Error())
+		assert.Equal(t, "Google", err.Metadata.ProviderName)
+	})
+
+	t.Run("html body", func(t *testing.T) {
+		err := newAPIError(http.StatusBadGateway, []byte("<html><body>502 Bad Gateway</body></html>\n"))
+
+		assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+		assert.Equal(t, "<html><body>502 Bad Gateway</body></html>", err.Message)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		err := newAPIError(http.StatusServiceUnavailable, nil)
+
+		assert.Equal(t, "API error: 503 - Service Unavailable", err.Error())
+	})
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		metadata   *ErrorMetadata
+		want       error
+	}{
+		{"bad request", http.StatusBadRequest, nil, ErrInvalidRequest},
+		{"unauthorized", http.StatusUnauthorized, nil, ErrUnauthorized},
+		{"payment required", http.StatusPaymentRequired, nil, ErrInsufficientCredits},
+		{"forbidden", http.StatusForbidden, nil, ErrModeration},
+		{"moderation reasons", http.StatusBadRequest, &ErrorMetadata{Reasons: []string{"violence"}}, ErrModeration},
+		{"too many requests", http.StatusTooManyRequests, nil, ErrRateLimited},
+		{"bad gateway", http.StatusBadGateway, nil, ErrProviderUnavailable},
+		{"service unavailable", http.StatusServiceUnavailable, nil, ErrProviderUnavailable},
+	}
+
+	sentinels := []error{ErrInvalidRequest, ErrUnauthorized, ErrInsufficientCredits, ErrModeration, ErrRateLimited, ErrProviderUnavailable}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error = &APIError{StatusCode: tt.statusCode, Metadata: tt.metadata}
+
+			assert.ErrorIs(t, err, tt.want)
+			for _, sentinel := range sentinels {
+				if sentinel != tt.want && !(tt.metadata != nil && sentinel == ErrInvalidRequest) {
+					assert.NotErrorIs(t, err, sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateContent_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		_, _ = w.Write([]byte(`{"error":{"code":402,"message":"Insufficient credits"}}`))
+	}))
+	defer server.Close()
+
+	_, err := ChatCompletion[struct {
+		Answer string `json:"answer"`
+	}]().GenerateContentWith(NewClient(WithBaseURL(server.URL)))
+
+	var apiErr *APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusPaymentRequired, apiErr.StatusCode)
+		assert.Equal(t, "Insufficient credits", apiErr.Message)
+	}
+	assert.ErrorIs(t, err, ErrInsufficientCredits)
+}
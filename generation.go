@@ -0,0 +1,96 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultGenerationPollInterval = 500 * time.Millisecond
+	defaultGenerationPollTimeout  = 10 * time.Second
+)
+
+// Generation holds the authoritative stats of a completion, as accounted by OpenRouter.
+type Generation struct {
+	ID                     string  `json:"id"`
+	UpstreamID             string  `json:"upstream_id"`
+	Model                  Model   `json:"model"`
+	ProviderName           string  `json:"provider_name"`
+	CreatedAt              string  `json:"created_at"`
+	TotalCost              float64 `json:"total_cost"`
+	CacheDiscount          float64 `json:"cache_discount"`
+	Usage                  float64 `json:"usage"`
+	IsBYOK                 bool    `json:"is_byok"`
+	Streamed               bool    `json:"streamed"`
+	Cancelled              bool    `json:"cancelled"`
+	Origin                 string  `json:"origin"`
+	FinishReason           string  `json:"finish_reason"`
+	NativeFinishReason     string  `json:"native_finish_reason"`
+	Latency                int     `json:"latency"`
+	ModerationLatency      int     `json:"moderation_latency"`
+	GenerationTime         int     `json:"generation_time"`
+	TokensPrompt           int     `json:"tokens_prompt"`
+	TokensCompletion       int     `json:"tokens_completion"`
+	NativeTokensPrompt     int     `json:"native_tokens_prompt"`
+	NativeTokensCompletion int     `json:"native_tokens_completion"`
+	NativeTokensReasoning  int     `json:"native_tokens_reasoning"`
+	NativeTokensCached     int     `json:"native_tokens_cached"`
+	NumMediaPrompt         int     `json:"num_media_prompt"`
+	NumMediaCompletion     int     `json:"num_media_completion"`
+	NumSearchResults       int     `json:"num_search_results"`
+}
+
+// GetGeneration returns the stats of the generation with the given ID. Stats are
+// only available shortly after the completion ends; until then, an APIError with
+// status 404 is returned.
+func (c *Client) GetGeneration(ctx context.Context, id string) (*Generation, error) {
+	return callData[*Generation](ctx, c, http.MethodGet, "/generation?id="+url.QueryEscape(id), nil)
+}
+
+// WithGenerationStats makes the client fetch the stats of every completion once it
+// is answered, polling every interval until they are available or timeout expires.
+// Streamed completions are covered once the stream is over, by Stream.Response.
+// Zero values default to 500ms and 10s.
+func WithGenerationStats(interval, timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if interval <= 0 {
+			interval = defaultGenerationPollInterval
+		}
+		if timeout <= 0 {
+			timeout = defaultGenerationPollTimeout
+		}
+		c.generationPolling = &generationPolling{interval: interval, timeout: timeout}
+	}
+}
+
+type generationPolling struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// pollGeneration waits for the stats of a generation to be available.
+func (c *Client) pollGeneration(ctx context.Context, id string) (*Generation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.generationPolling.timeout)
+	defer cancel()
+
+	for {
+		generation, err := c.GetGeneration(ctx, id)
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			if err = sleep(ctx, c.generationPolling.interval); err == nil {
+				continue
+			}
+		}
+
+		if err != nil && ctx.Err() != nil {
+			return nil, fmt.Errorf("generation %s is still not available: %w", id, err)
+		}
+
+		return generation, err
+	}
+}
@@ -0,0 +1,154 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const generationResponse = `{"data": {
+	"id": "gen-123",
+	"upstream_id": "chatcmpl-456",
+	"model": "google/gemini-2.5-flash-lite",
+	"provider_name": "Google",
+	"created_at": "2025-01-01T12:00:00Z",
+	"total_cost": 0.00012,
+	"usage": 0.00012,
+	"is_byok": false,
+	"streamed": false,
+	"cancelled": false,
+	"finish_reason": "stop",
+	"native_finish_reason": "STOP",
+	"latency": 850,
+	"generation_time": 600,
+	"tokens_prompt": 10,
+	"tokens_completion": 5,
+	"native_tokens_prompt": 12,
+	"native_tokens_completion": 6,
+	"native_tokens_reasoning": 0,
+	"native_tokens_cached": 4
+}}`
+
+func TestClient_GetGeneration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/generation", r.URL.Path)
+		assert.Equal(t, "gen-123", r.URL.Query().Get("id"))
+
+		_, _ = w.Write([]byte(generationResponse))
+	}))
+	defer server.Close()
+
+	generation, err := NewClient(WithBaseURL(server.URL)).GetGeneration(context.Background(), "gen-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "gen-123", generation.ID)
+	assert.Equal(t, "Google", generation.ProviderName)
+	assert.Equal(t, 0.00012, generation.TotalCost)
+	assert.Equal(t, 850, generation.Latency)
+	assert.Equal(t, 12, generation.NativeTokensPrompt)
+	assert.Equal(t, 4, generation.NativeTokensCached)
+}
+
+func TestClient_WithGenerationStats(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	var lookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/generation" {
+			if lookups.Add(1) < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Generation not found"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(generationResponse))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"gen-123","choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	t.Run("polls until available", func(t *testing.T) {
+		client := NewClient(WithBaseURL(server.URL), WithGenerationStats(time.Millisecond, time.Second))
+
+		res, err := ChatCompletion[testResponse]().GenerateResponse(context.Background(), client)
+
+		assert.NoError(t, err)
+		assert.NoError(t, res.GenerationErr)
+		if assert.NotNil(t, res.Generation) {
+			assert.Equal(t, 0.00012, res.Generation.TotalCost)
+		}
+		assert.EqualValues(t, 3, lookups.Load())
+	})
+
+	t.Run("streamed completion", func(t *testing.T) {
+		lookups.Store(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/generation" {
+				lookups.Add(1)
+				assert.Equal(t, "gen-123", r.URL.Query().Get("id"))
+				_, _ = w.Write([]byte(generationResponse))
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"id\":\"gen-123\",\"choices\":[{\"delta\":{\"content\":\"{\\\"answer\\\":\\\"ok\\\"}\"},\"finish_reason\":\"stop\"}]}\n\n" +
+				"data: [DONE]\n\n"))
+		}))
+		defer server.Close()
+		client := NewClient(WithBaseURL(server.URL), WithGenerationStats(time.Millisecond, time.Second))
+
+		stream, err := ChatCompletion[testResponse]().Stream(context.Background(), client)
+		assert.NoError(t, err)
+		defer stream.Close()
+		for stream.Next() {
+		}
+
+		res, err := stream.Response()
+		assert.NoError(t, err)
+		assert.NoError(t, res.GenerationErr)
+		if assert.NotNil(t, res.Generation) {
+			assert.Equal(t, 0.00012, res.Generation.TotalCost)
+		}
+
+		_, err = stream.Result()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, lookups.Load(), "the stats must be fetched once")
+	})
+
+	t.Run("gives up after timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/generation" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Generation not found"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"gen-123","choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`))
+		}))
+		defer server.Close()
+		client := NewClient(WithBaseURL(server.URL), WithGenerationStats(time.Millisecond, 20*time.Millisecond))
+
+		res, err := ChatCompletion[testResponse]().GenerateResponse(context.Background(), client)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &testResponse{Answer: "ok"}, res.Value)
+		assert.Nil(t, res.Generation)
+		assert.ErrorContains(t, res.GenerationErr, "generation gen-123 is still not available")
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		lookups.Store(0)
+
+		res, err := ChatCompletion[testResponse]().GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.NoError(t, err)
+		assert.Nil(t, res.Generation)
+		assert.Zero(t, lookups.Load())
+	})
+}
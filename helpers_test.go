@@ -13,3 +13,7 @@ func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 	return &http.Response{}, nil
 }
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,188 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// LimitReset tells how often the credit limit of a key resets.
+type LimitReset string
+
+const (
+	LimitResetDaily   LimitReset = "daily"
+	LimitResetWeekly  LimitReset = "weekly"
+	LimitResetMonthly LimitReset = "monthly"
+)
+
+// Key is an API key managed with a provisioning key. Keys are identified by their
+// hash; the key itself is only returned once, on creation.
+type Key struct {
+	Hash     string `json:"hash"`
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Disabled bool   `json:"disabled"`
+	// Limit is the credit limit of the key in USD, nil when unlimited.
+	Limit *float64 `json:"limit"`
+	// LimitRemaining is the credits left before reaching Limit, nil when unlimited.
+	LimitRemaining     *float64   `json:"limit_remaining"`
+	LimitReset         LimitReset `json:"limit_reset"`
+	IncludeBYOKInLimit bool       `json:"include_byok_in_limit"`
+	Usage              float64    `json:"usage"`
+	UsageDaily         float64    `json:"usage_daily"`
+	UsageWeekly        float64    `json:"usage_weekly"`
+	UsageMonthly       float64    `json:"usage_monthly"`
+	CreatedAt          string     `json:"created_at"`
+	UpdatedAt          string     `json:"updated_at"`
+}
+
+// ListKeysOptions paginates and filters ListKeys.
+type ListKeysOptions struct {
+	Offset          int
+	IncludeDisabled bool
+}
+
+// CreateKeyParams describes a key to create.
+type CreateKeyParams struct {
+	Name string `json:"name"`
+	// Limit is the credit limit of the key in USD. Nil creates an unlimited key.
+	Limit              *float64   `json:"limit,omitempty"`
+	LimitReset         LimitReset `json:"limit_reset,omitempty"`
+	IncludeBYOKInLimit bool       `json:"include_byok_in_limit,omitempty"`
+}
+
+// UpdateKeyParams lists the changes to apply to a key. Nil fields are left unchanged.
+type UpdateKeyParams struct {
+	Name               *string     `json:"name,omitempty"`
+	Disabled           *bool       `json:"disabled,omitempty"`
+	Limit              *float64    `json:"limit,omitempty"`
+	LimitReset         *LimitReset `json:"limit_reset,omitempty"`
+	IncludeBYOKInLimit *bool       `json:"include_byok_in_limit,omitempty"`
+	// RemoveLimit makes the key unlimited. It cannot be combined with Limit.
+	RemoveLimit bool `json:"-"`
+}
+
+func (p UpdateKeyParams) MarshalJSON() ([]byte, error) {
+	type Alias UpdateKeyParams
+	if !p.RemoveLimit {
+		return json.Marshal((Alias)(p))
+	}
+
+	return json.Marshal(&struct {
+		Alias
+		Limit *float64 `json:"limit"`
+	}{
+		Alias: (Alias)(p),
+	})
+}
+
+// CreatedKey is a newly created key along with its secret value.
+type CreatedKey struct {
+	Key
+	// Secret is the API key itself. It cannot be retrieved again.
+	Secret string
+}
+
+// ListKeys returns the keys managed by the client's provisioning key.
+func (c *Client) ListKeys(ctx context.Context, opts ListKeysOptions) ([]Key, error) {
+	query := url.Values{}
+	if opts.Offset > 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	if opts.IncludeDisabled {
+		query.Set("include_disabled", "true")
+	}
+
+	path := "/keys"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	return callData[[]Key](ctx, c, http.MethodGet, path, nil)
+}
+
+// CreateKey creates a key with the client's provisioning key.
+func (c *Client) CreateKey(ctx context.Context, params CreateKeyParams) (*CreatedKey, error) {
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	body, err := c.call(ctx, http.MethodPost, "/keys", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data Key    `json:"data"`
+		Key  string `json:"key"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return &CreatedKey{Key: result.Data, Secret: result.Key}, nil
+}
+
+// GetKey returns the key with the given hash.
+func (c *Client) GetKey(ctx context.Context, hash string) (*Key, error) {
+	return callData[*Key](ctx, c, http.MethodGet, keyPath(hash), nil)
+}
+
+// UpdateKey applies params to the key with the given hash and returns it updated.
+func (c *Client) UpdateKey(ctx context.Context, hash string, params UpdateKeyParams) (*Key, error) {
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	return callData[*Key](ctx, c, http.MethodPatch, keyPath(hash), params)
+}
+
+// DeleteKey deletes the key with the given hash.
+func (c *Client) DeleteKey(ctx context.Context, hash string) error {
+	_, err := c.call(ctx, http.MethodDelete, keyPath(hash), nil)
+	return err
+}
+
+func keyPath(hash string) string {
+	return "/keys/" + url.PathEscape(hash)
+}
+
+func (p CreateKeyParams) validate() error {
+	if p.Name == "" {
+		return errors.New("key name cannot be empty")
+	}
+
+	return validateKeyLimit(p.Limit, &p.LimitReset)
+}
+
+func (p UpdateKeyParams) validate() error {
+	if p.Name != nil && *p.Name == "" {
+		return errors.New("key name cannot be empty")
+	}
+
+	if p.RemoveLimit && p.Limit != nil {
+		return errors.New("key limit cannot be both set and removed")
+	}
+
+	return validateKeyLimit(p.Limit, p.LimitReset)
+}
+
+func validateKeyLimit(limit *float64, reset *LimitReset) error {
+	if limit != nil && *limit < 0 {
+		return errors.New("key limit cannot be negative")
+	}
+
+	if reset != nil {
+		switch *reset {
+		case "", LimitResetDaily, LimitResetWeekly, LimitResetMonthly:
+		default:
+			return fmt.Errorf("unsupported limit reset: %s", *reset)
+		}
+	}
+
+	return nil
+}
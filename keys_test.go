@@ -0,0 +1,131 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const keyResponse = `{
+	"hash": "f01d52606dc8f0a8303a7b5cc3fa07109c2e346cec7c0a16b40de462992ce943",
+	"name": "tenant-42",
+	"label": "sk-or-v1-0e6...1c96",
+	"disabled": false,
+	"limit": 10,
+	"limit_remaining": 7.5,
+	"limit_reset": "monthly",
+	"include_byok_in_limit": false,
+	"usage": 2.5,
+	"usage_daily": 0.5,
+	"usage_weekly": 1.5,
+	"usage_monthly": 2.5,
+	"created_at": "2025-01-01T12:00:00Z",
+	"updated_at": null
+}`
+
+func TestClient_Keys(t *testing.T) {
+	const hash = "f01d52606dc8f0a8303a7b5cc3fa07109c2e346cec7c0a16b40de462992ce943"
+
+	type call struct {
+		method, path, query string
+		body                map[string]any
+	}
+	var calls []call
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer provisioning-key", r.Header.Get("Authorization"))
+
+		c := call{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			assert.NoError(t, json.Unmarshal(data, &c.body))
+		}
+		calls = append(calls, c)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/keys":
+			_, _ = w.Write([]byte(`{"data":[` + keyResponse + `]}`))
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"data":` + keyResponse + `,"key":"sk-or-v1-secret"}`))
+		case r.Method == http.MethodDelete:
+			_, _ = w.Write([]byte(`{"deleted":true}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":` + keyResponse + `}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("provisioning-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+	want := Key{
+		Hash:           hash,
+		Name:           "tenant-42",
+		Label:          "sk-or-v1-0e6...1c96",
+		Limit:          ptr(10.0),
+		LimitRemaining: ptr(7.5),
+		LimitReset:     LimitResetMonthly,
+		Usage:          2.5,
+		UsageDaily:     0.5,
+		UsageWeekly:    1.5,
+		UsageMonthly:   2.5,
+		CreatedAt:      "2025-01-01T12:00:00Z",
+	}
+
+	keys, err := client.ListKeys(ctx, ListKeysOptions{Offset: 100, IncludeDisabled: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Key{want}, keys)
+
+	created, err := client.CreateKey(ctx, CreateKeyParams{Name: "tenant-42", Limit: ptr(10.0), LimitReset: LimitResetMonthly})
+	assert.NoError(t, err)
+	assert.Equal(t, &CreatedKey{Key: want, Secret: "sk-or-v1-secret"}, created)
+
+	key, err := client.GetKey(ctx, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, &want, key)
+
+	key, err = client.UpdateKey(ctx, hash, UpdateKeyParams{Disabled: ptr(true), Limit: ptr(20.0)})
+	assert.NoError(t, err)
+	assert.Equal(t, &want, key)
+
+	_, err = client.UpdateKey(ctx, hash, UpdateKeyParams{RemoveLimit: true})
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.DeleteKey(ctx, hash))
+
+	assert.Equal(t, []call{
+		{method: http.MethodGet, path: "/keys", query: "include_disabled=true&offset=100"},
+		{method: http.MethodPost, path: "/keys", body: map[string]any{"name": "tenant-42", "limit": 10.0, "limit_reset": "monthly"}},
+		{method: http.MethodGet, path: "/keys/" + hash},
+		{method: http.MethodPatch, path: "/keys/" + hash, body: map[string]any{"disabled": true, "limit": 20.0}},
+		{method: http.MethodPatch, path: "/keys/" + hash, body: map[string]any{"limit": nil}},
+		{method: http.MethodDelete, path: "/keys/" + hash},
+	}, calls)
+}
+
+func TestClient_Keys_Validation(t *testing.T) {
+	client := NewClient(WithHTTPClient(&http.Client{Transport: &MockTransport{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		t.Fatal("the request must not be sent")
+		return nil, nil
+	}}}))
+	ctx := context.Background()
+
+	_, err := client.CreateKey(ctx, CreateKeyParams{})
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+	assert.ErrorContains(t, err, "key name cannot be empty")
+
+	_, err = client.CreateKey(ctx, CreateKeyParams{Name: "tenant", Limit: ptr(-1.0)})
+	assert.ErrorContains(t, err, "key limit cannot be negative")
+
+	_, err = client.CreateKey(ctx, CreateKeyParams{Name: "tenant", LimitReset: "yearly"})
+	assert.ErrorContains(t, err, "unsupported limit reset: yearly")
+
+	_, err = client.UpdateKey(ctx, "hash", UpdateKeyParams{Name: ptr("")})
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = client.UpdateKey(ctx, "hash", UpdateKeyParams{Limit: ptr(5.0), RemoveLimit: true})
+	assert.ErrorContains(t, err, "key limit cannot be both set and removed")
+}
@@ -0,0 +1,47 @@
+package openrouter
+
+import "encoding/json"
+
+type AssistantMessage struct {
+	Content   string     `json:"content"`
+	Name      string     `json:"name,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// Refusal is set instead of Content when the model declined to answer.
+	Refusal string `json:"refusal,omitempty"`
+	// Reasoning is the plain text reasoning of the model, when exposed.
+	Reasoning string `json:"reasoning,omitempty"`
+	// ReasoningDetails must be sent back unmodified for reasoning models to resume
+	// from their previous reasoning, in particular across tool calls.
+	ReasoningDetails []ReasoningDetail `json:"reasoning_details,omitempty"`
+	// Annotations returned with the message, such as parsed files, to send back as is.
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+func (m AssistantMessage) Role() string {
+	return "assistant"
+}
+
+func (m AssistantMessage) MarshalJSON() ([]byte, error) {
+	type Alias AssistantMessage
+	return json.Marshal(&struct {
+		Role string `json:"role"`
+		Alias
+	}{
+		Role:  m.Role(),
+		Alias: (Alias)(m),
+	})
+}
+
+// ReasoningDetail is a block of reasoning returned by a model. Depending on Type,
+// it holds a summary, plain text or encrypted data.
+type ReasoningDetail struct {
+	// Type is reasoning.summary, reasoning.text or reasoning.encrypted.
+	Type      string `json:"type"`
+	ID        string `json:"id,omitempty"`
+	Format    string `json:"format,omitempty"`
+	Index     int    `json:"index"`
+	Summary   string `json:"summary,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Data      string `json:"data,omitempty"`
+}
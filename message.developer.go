@@ -0,0 +1,24 @@
+package openrouter
+
+import "encoding/json"
+
+// DeveloperMessage carries instructions for models that tell them apart from system prompts.
+type DeveloperMessage struct {
+	Content string `json:"content"`
+	Name    string `json:"name,omitempty"`
+}
+
+func (m DeveloperMessage) Role() string {
+	return "developer"
+}
+
+func (m DeveloperMessage) MarshalJSON() ([]byte, error) {
+	type Alias DeveloperMessage
+	return json.Marshal(&struct {
+		Role string `json:"role"`
+		Alias
+	}{
+		Role:  m.Role(),
+		Alias: (Alias)(m),
+	})
+}
@@ -1,6 +1,45 @@
 package openrouter
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Message is the interface for different message types.
 type Message interface {
 	Role() string
 }
+
+// unmarshalMessage decodes a message into the type matching its role.
+func unmarshalMessage(data []byte) (Message, error) {
+	var header struct {
+		Role string `json:"role"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	switch header.Role {
+	case "system":
+		return unmarshalAs[SystemMessage](data)
+	case "developer":
+		return unmarshalAs[DeveloperMessage](data)
+	case "user":
+		return unmarshalAs[UserMessage](data)
+	case "assistant":
+		return unmarshalAs[AssistantMessage](data)
+	case "tool":
+		return unmarshalAs[ToolMessage](data)
+	}
+
+	return nil, fmt.Errorf("unsupported message role: %q", header.Role)
+}
+
+func unmarshalAs[M Message](data []byte) (Message, error) {
+	var m M
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -5,6 +5,9 @@ import "encoding/json"
 type SystemMessage struct {
 	Content string `json:"content"`
 	Name    string `json:"name,omitempty"`
+	// Parts splits the prompt into text parts that can carry cache breakpoints. When
+	// both are set, Content is sent as the first part.
+	Parts []TextContent `json:"-"`
 }
 
 func (m SystemMessage) Role() string {
@@ -13,6 +16,23 @@ func (m SystemMessage) Role() string {
 
 func (m SystemMessage) MarshalJSON() ([]byte, error) {
 	type Alias SystemMessage
+	if len(m.Parts) > 0 {
+		parts := m.Parts
+		if m.Content != "" {
+			parts = append([]TextContent{{Text: m.Content}}, parts...)
+		}
+
+		return json.Marshal(&struct {
+			Role    string        `json:"role"`
+			Content []TextContent `json:"content"`
+			Name    string        `json:"name,omitempty"`
+		}{
+			Role:    m.Role(),
+			Content: parts,
+			Name:    m.Name,
+		})
+	}
+
 	return json.Marshal(&struct {
 		Role string `json:"role"`
 		Alias
@@ -21,3 +41,24 @@ func (m SystemMessage) MarshalJSON() ([]byte, error) {
 		Alias: (Alias)(m),
 	})
 }
+
+func (m *SystemMessage) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Content json.RawMessage `json:"content"`
+		Name    string          `json:"name"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*m = SystemMessage{Name: aux.Name}
+	if len(aux.Content) == 0 || string(aux.Content) == "null" {
+		return nil
+	}
+
+	if err := json.Unmarshal(aux.Content, &m.Content); err == nil {
+		return nil
+	}
+
+	return json.Unmarshal(aux.Content, &m.Parts)
+}
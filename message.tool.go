@@ -0,0 +1,23 @@
+package openrouter
+
+import "encoding/json"
+
+type ToolMessage struct {
+	ToolCallID string `json:"tool_call_id"`
+	Content    string `json:"content"`
+}
+
+func (m ToolMessage) Role() string {
+	return "tool"
+}
+
+func (m ToolMessage) MarshalJSON() ([]byte, error) {
+	type Alias ToolMessage
+	return json.Marshal(&struct {
+		Role string `json:"role"`
+		Alias
+	}{
+		Role:  m.Role(),
+		Alias: (Alias)(m),
+	})
+}
@@ -21,3 +21,39 @@ func (m UserMessage) MarshalJSON() ([]byte, error) {
 		Alias: (Alias)(m),
 	})
 }
+
+func (m *UserMessage) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Content json.RawMessage `json:"content"`
+		Name    string          `json:"name"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	m.Name = aux.Name
+	m.Content = nil
+	if len(aux.Content) == 0 || string(aux.Content) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(aux.Content, &text); err == nil {
+		m.Content = []Content{TextContent{Text: text}}
+		return nil
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal(aux.Content, &parts); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		c, err := unmarshalContent(part)
+		if err != nil {
+			return err
+		}
+		m.Content = append(m.Content, c)
+	}
+
+	return nil
+}
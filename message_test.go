@@ -0,0 +1,141 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		message Message
+		want    string
+	}{{
+		name:    "system",
+		message: SystemMessage{Content: "Be concise"},
+		want:    `{"role":"system","content":"Be concise"}`,
+	}, {
+		name: "system with cached parts",
+		message: SystemMessage{
+			Parts: []TextContent{
+				{Text: "Long instructions", CacheControl: EphemeralCache(CacheTTL1Hour)},
+				{Text: "Today is Monday"},
+			},
+		},
+		want: `{"role":"system","content":[
+			{"type":"text","text":"Long instructions","cache_control":{"type":"ephemeral","ttl":"1h"}},
+			{"type":"text","text":"Today is Monday"}
+		]}`,
+	}, {
+		name: "system with content and cached parts",
+		message: SystemMessage{
+			Content: "Be concise",
+			Parts:   []TextContent{{Text: "Long instructions", CacheControl: EphemeralCache("")}},
+		},
+		want: `{"role":"system","content":[
+			{"type":"text","text":"Be concise"},
+			{"type":"text","text":"Long instructions","cache_control":{"type":"ephemeral"}}
+		]}`,
+	}, {
+		name:    "user with cache breakpoint",
+		message: UserMessage{Content: []Content{TextContent{Text: "Long document", CacheControl: EphemeralCache("")}}},
+		want:    `{"role":"user","content":[{"type":"text","text":"Long document","cache_control":{"type":"ephemeral"}}]}`,
+	}, {
+		name:    "developer",
+		message: DeveloperMessage{Content: "Answer in French", Name: "rules"},
+		want:    `{"role":"developer","content":"Answer in French","name":"rules"}`,
+	}, {
+		name:    "user",
+		message: UserMessage{Content: []Content{TextContent{Text: "Hello"}}},
+		want:    `{"role":"user","content":[{"type":"text","text":"Hello"}]}`,
+	}, {
+		name:    "assistant text",
+		message: AssistantMessage{Content: `{"answer":"Paris"}`},
+		want:    `{"role":"assistant","content":"{\"answer\":\"Paris\"}"}`,
+	}, {
+		name: "assistant tool calls and reasoning",
+		message: AssistantMessage{
+			ToolCalls: []ToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+			}},
+			ReasoningDetails: []ReasoningDetail{{Type: "reasoning.encrypted", ID: "rs_1", Format: "openai-responses-v1", Data: "abc"}},
+		},
+		want: `{
+			"role": "assistant",
+			"content": "",
+			"tool_calls": [{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}],
+			"reasoning_details": [{"type":"reasoning.encrypted","id":"rs_1","format":"openai-responses-v1","index":0,"data":"abc"}]
+		}`,
+	}, {
+		name:    "assistant refusal",
+		message: AssistantMessage{Refusal: "I cannot help with that"},
+		want:    `{"role":"assistant","content":"","refusal":"I cannot help with that"}`,
+	}, {
+		name:    "tool",
+		message: ToolMessage{ToolCallID: "call_1", Content: `{"temperature":5}`},
+		want:    `{"role":"tool","tool_call_id":"call_1","content":"{\"temperature\":5}"}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.message)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestSystemMessage_UnmarshalJSON(t *testing.T) {
+	messages := []SystemMessage{
+		{Content: "Be concise", Name: "rules"},
+		{Parts: []TextContent{{Text: "Long instructions", CacheControl: EphemeralCache(CacheTTL5Minutes)}, {Text: "Today is Monday"}}},
+	}
+
+	for _, want := range messages {
+		data, err := json.Marshal(want)
+		assert.NoError(t, err)
+
+		var got SystemMessage
+		assert.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestResponse_Message(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"choices":[{"message":{
+			"content": "",
+			"refusal": "I cannot help with that",
+			"reasoning": "The user asks for something harmful",
+			"reasoning_details": [{"type":"reasoning.text","text":"The user asks for something harmful","signature":"sig","index":0}]
+		}}]}`))
+	}))
+	defer server.Close()
+
+	req := ChatCompletion[testResponse]()
+	client := NewClient(WithBaseURL(server.URL))
+
+	res, err := req.GenerateResponse(context.Background(), client)
+	assert.NoError(t, err)
+	assert.Nil(t, res.Value)
+	assert.Equal(t, AssistantMessage{
+		Refusal:          "I cannot help with that",
+		Reasoning:        "The user asks for something harmful",
+		ReasoningDetails: []ReasoningDetail{{Type: "reasoning.text", Text: "The user asks for something harmful", Signature: "sig"}},
+	}, res.Message())
+
+	_, err = req.GenerateContentWith(client)
+	assert.EqualError(t, err, "unexpected error: the model refused to answer: I cannot help with that")
+}
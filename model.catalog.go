@@ -0,0 +1,180 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// Modality is a kind of input or output a model handles.
+type Modality string
+
+const (
+	ModalityText  Modality = "text"
+	ModalityImage Modality = "image"
+	ModalityFile  Modality = "file"
+	ModalityAudio Modality = "audio"
+	ModalityVideo Modality = "video"
+)
+
+// Parameters reported in ModelInfo.SupportedParameters.
+const (
+	ParameterTools             = "tools"
+	ParameterToolChoice        = "tool_choice"
+	ParameterResponseFormat    = "response_format"
+	ParameterStructuredOutputs = "structured_outputs"
+	ParameterReasoning         = "reasoning"
+)
+
+// Price is an amount in USD. OpenRouter sends prices as decimal strings.
+type Price float64
+
+func (p *Price) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var f float64
+		if err := json.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("invalid price: %s", data)
+		}
+		*p = Price(f)
+		return nil
+	}
+
+	if s == "" {
+		*p = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid price: %w", err)
+	}
+	*p = Price(f)
+
+	return nil
+}
+
+// ModelPricing holds the prices of a model, per token for prompt and completion
+// and per unit for the others.
+type ModelPricing struct {
+	Prompt            Price `json:"prompt"`
+	Completion        Price `json:"completion"`
+	Request           Price `json:"request"`
+	Image             Price `json:"image"`
+	WebSearch         Price `json:"web_search"`
+	InternalReasoning Price `json:"internal_reasoning"`
+	InputCacheRead    Price `json:"input_cache_read"`
+	InputCacheWrite   Price `json:"input_cache_write"`
+}
+
+// ModelArchitecture describes what a model takes in and produces.
+type ModelArchitecture struct {
+	InputModalities  []Modality `json:"input_modalities"`
+	OutputModalities []Modality `json:"output_modalities"`
+	Tokenizer        string     `json:"tokenizer"`
+	InstructType     string     `json:"instruct_type"`
+}
+
+// TopProvider holds the limits of the main provider serving a model.
+type TopProvider struct {
+	ContextLength       int  `json:"context_length"`
+	MaxCompletionTokens int  `json:"max_completion_tokens"`
+	IsModerated         bool `json:"is_moderated"`
+}
+
+// ModelInfo is the metadata OpenRouter publishes about a model.
+type ModelInfo struct {
+	ID                  Model             `json:"id"`
+	CanonicalSlug       string            `json:"canonical_slug"`
+	Name                string            `json:"name"`
+	Description         string            `json:"description"`
+	Created             int64             `json:"created"`
+	ContextLength       int               `json:"context_length"`
+	Architecture        ModelArchitecture `json:"architecture"`
+	Pricing             ModelPricing      `json:"pricing"`
+	TopProvider         TopProvider       `json:"top_provider"`
+	SupportedParameters []string          `json:"supported_parameters"`
+}
+
+// SupportsParameter reports whether the model accepts the given request parameter.
+func (m ModelInfo) SupportsParameter(parameter string) bool {
+	return slices.Contains(m.SupportedParameters, parameter)
+}
+
+// SupportsStructuredOutputs reports whether the model can follow a JSON schema.
+func (m ModelInfo) SupportsStructuredOutputs() bool {
+	return m.SupportsParameter(ParameterStructuredOutputs)
+}
+
+// SupportsTools reports whether the model can call tools.
+func (m ModelInfo) SupportsTools() bool {
+	return m.SupportsParameter(ParameterTools)
+}
+
+// AcceptsInput reports whether the model accepts the given input modality.
+func (m ModelInfo) AcceptsInput(modality Modality) bool {
+	return slices.Contains(m.Architecture.InputModalities, modality)
+}
+
+// AcceptsImages reports whether the model accepts images as input.
+func (m ModelInfo) AcceptsImages() bool {
+	return m.AcceptsInput(ModalityImage)
+}
+
+// ProducesOutput reports whether the model can generate the given output modality.
+func (m ModelInfo) ProducesOutput(modality Modality) bool {
+	return slices.Contains(m.Architecture.OutputModalities, modality)
+}
+
+// ModelList is a list of models, with helpers to filter it.
+type ModelList []ModelInfo
+
+// Filter returns the models matching keep.
+func (l ModelList) Filter(keep func(ModelInfo) bool) ModelList {
+	var filtered ModelList
+	for _, m := range l {
+		if keep(m) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
+
+// Find returns the model with the given ID.
+func (l ModelList) Find(id Model) (ModelInfo, bool) {
+	i := slices.IndexFunc(l, func(m ModelInfo) bool { return m.ID == id })
+	if i < 0 {
+		return ModelInfo{}, false
+	}
+
+	return l[i], true
+}
+
+// SupportingParameter returns the models accepting the given request parameter.
+func (l ModelList) SupportingParameter(parameter string) ModelList {
+	return l.Filter(func(m ModelInfo) bool { return m.SupportsParameter(parameter) })
+}
+
+// SupportingStructuredOutputs returns the models that can follow a JSON schema.
+func (l ModelList) SupportingStructuredOutputs() ModelList {
+	return l.Filter(ModelInfo.SupportsStructuredOutputs)
+}
+
+// AcceptingInput returns the models accepting the given input modality.
+func (l ModelList) AcceptingInput(modality Modality) ModelList {
+	return l.Filter(func(m ModelInfo) bool { return m.AcceptsInput(modality) })
+}
+
+// AcceptingImages returns the models accepting images as input.
+func (l ModelList) AcceptingImages() ModelList {
+	return l.AcceptingInput(ModalityImage)
+}
+
+// ListModels returns the models available on OpenRouter.
+func (c *Client) ListModels(ctx context.Context) (ModelList, error) {
+	return callData[ModelList](ctx, c, http.MethodGet, "/models", nil)
+}
@@ -0,0 +1,131 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const modelsResponse = `{"data": [
+	{
+		"id": "google/gemini-2.5-flash-lite",
+		"canonical_slug": "google/gemini-2.5-flash-lite",
+		"name": "Google: Gemini 2.5 Flash Lite",
+		"created": 1753200276,
+		"description": "Gemini 2.5 Flash-Lite is a lightweight reasoning model.",
+		"context_length": 1048576,
+		"architecture": {
+			"modality": "text+image->text",
+			"input_modalities": ["text", "image", "file", "audio"],
+			"output_modalities": ["text"],
+			"tokenizer": "Gemini",
+			"instruct_type": null
+		},
+		"pricing": {
+			"prompt": "0.0000001",
+			"completion": "0.0000004",
+			"request": "0",
+			"image": "0",
+			"input_cache_read": "0.000000025"
+		},
+		"top_provider": {
+			"context_length": 1048576,
+			"max_completion_tokens": 65535,
+			"is_moderated": false
+		},
+		"per_request_limits": null,
+		"supported_parameters": ["max_tokens", "temperature", "tools", "tool_choice", "reasoning", "response_format", "structured_outputs", "seed"]
+	},
+	{
+		"id": "meta-llama/llama-3-8b-instruct",
+		"name": "Meta: Llama 3 8B Instruct",
+		"context_length": 8192,
+		"architecture": {
+			"input_modalities": ["text"],
+			"output_modalities": ["text"],
+			"tokenizer": "Llama3",
+			"instruct_type": "llama3"
+		},
+		"pricing": {"prompt": "0.00000003", "completion": "0.00000006"},
+		"top_provider": {"context_length": 8192, "max_completion_tokens": null, "is_moderated": false},
+		"supported_parameters": ["max_tokens", "temperature", "top_k"]
+	},
+	{
+		"id": "mistralai/mistral-7b-instruct",
+		"name": "Mistral: Mistral 7B Instruct",
+		"context_length": 32768,
+		"architecture": {
+			"input_modalities": ["text"],
+			"output_modalities": ["text"],
+			"tokenizer": "Mistral",
+			"instruct_type": "mistral"
+		},
+		"pricing": {"prompt": "0.000000028", "completion": "0.000000054"},
+		"top_provider": {"context_length": 32768, "max_completion_tokens": 16384, "is_moderated": false},
+		"supported_parameters": ["max_tokens", "temperature", "response_format", "tools", "tool_choice"]
+	}
+]}`
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/models", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(modelsResponse))
+	}))
+	defer server.Close()
+
+	models, err := NewClient(WithAPIKey("key"), WithBaseURL(server.URL)).ListModels(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	gemini, ok := models.Find(ModelGemini2_5FlashLite)
+	if assert.True(t, ok) {
+		assert.Equal(t, "Google: Gemini 2.5 Flash Lite", gemini.Name)
+		assert.Equal(t, 1048576, gemini.ContextLength)
+		assert.Equal(t, "Gemini", gemini.Architecture.Tokenizer)
+		assert.Equal(t, Price(0.0000001), gemini.Pricing.Prompt)
+		assert.Equal(t, Price(0.0000004), gemini.Pricing.Completion)
+		assert.Equal(t, Price(0.000000025), gemini.Pricing.InputCacheRead)
+		assert.Equal(t, 65535, gemini.TopProvider.MaxCompletionTokens)
+		assert.True(t, gemini.SupportsStructuredOutputs())
+		assert.True(t, gemini.SupportsTools())
+		assert.True(t, gemini.AcceptsImages())
+		assert.True(t, gemini.AcceptsInput(ModalityAudio))
+		assert.True(t, gemini.ProducesOutput(ModalityText))
+		assert.False(t, gemini.ProducesOutput(ModalityImage))
+	}
+
+	llama, ok := models.Find("meta-llama/llama-3-8b-instruct")
+	if assert.True(t, ok) {
+		assert.Equal(t, "llama3", llama.Architecture.InstructType)
+		assert.False(t, llama.SupportsStructuredOutputs())
+		assert.True(t, llama.SupportsParameter("top_k"))
+	}
+
+	_, ok = models.Find("unknown/model")
+	assert.False(t, ok)
+
+	assert.Len(t, models.SupportingStructuredOutputs(), 1)
+	assert.Len(t, models.AcceptingImages(), 1)
+	assert.Len(t, models.AcceptingInput(ModalityText), 3)
+	assert.Len(t, models.SupportingParameter("temperature"), 3)
+	assert.Empty(t, models.Filter(func(m ModelInfo) bool { return m.ContextLength > 2_000_000 }))
+}
+
+func TestClient_ListModels_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"code":401,"message":"No auth credentials found"}}`))
+	}))
+	defer server.Close()
+
+	models, err := NewClient(WithBaseURL(server.URL)).ListModels(context.Background())
+
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Nil(t, models)
+}
@@ -1,5 +1,7 @@
 package openrouter
 
+import "strings"
+
 type Model string
 
 const (
@@ -13,3 +15,19 @@ const (
 func (m Model) String() string {
 	return string(m)
 }
+
+// base returns the model without its variant, e.g. openai/gpt-5.2 for openai/gpt-5.2:online.
+func (m Model) base() Model {
+	base, _, _ := strings.Cut(string(m), ":")
+	return Model(base)
+}
+
+// Online returns the variant of the model grounded with web search results,
+// e.g. openai/gpt-5.2:online.
+func (m Model) Online() Model {
+	if strings.HasSuffix(string(m), ":online") {
+		return m
+	}
+
+	return m + ":online"
+}
@@ -0,0 +1,97 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// decodePartial decodes the longest meaningful prefix of a truncated JSON document
+// into v. It first tries to close the document as is, keeping a trailing partial
+// string, then falls back to the last complete member.
+func decodePartial(data string, v any) error {
+	candidate, fallback := completeJSON(data)
+
+	err := json.Unmarshal([]byte(candidate), v)
+	if err != nil && fallback != candidate {
+		err = json.Unmarshal([]byte(fallback), v)
+	}
+
+	return err
+}
+
+// completeJSON returns two attempts at turning a truncated JSON document into a
+// valid one: the whole input with its open string and containers closed, and the
+// input cut at the last point where a value was known to be complete.
+func completeJSON(data string) (candidate, fallback string) {
+	var (
+		stack    []byte
+		inString bool
+		escaped  bool
+		safeAt   = -1
+		safe     []byte
+	)
+
+	markSafe := func(at int) {
+		safeAt = at
+		safe = append(safe[:0], stack...)
+	}
+
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch ch {
+		case '"':
+			inString = true
+		case '{', '[':
+			stack = append(stack, ch)
+			markSafe(i + 1)
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			markSafe(i + 1)
+		case ',':
+			markSafe(i)
+		}
+	}
+
+	body := data
+	if inString {
+		if escaped {
+			body = body[:len(body)-1]
+		}
+		body += `"`
+	}
+	candidate = body + closers(stack)
+
+	if safeAt < 0 {
+		return candidate, candidate
+	}
+
+	return candidate, data[:safeAt] + closers(safe)
+}
+
+func closers(stack []byte) string {
+	var sb strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == '{' {
+			sb.WriteByte('}')
+		} else {
+			sb.WriteByte(']')
+		}
+	}
+
+	return sb.String()
+}
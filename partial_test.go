@@ -0,0 +1,55 @@
+package openrouter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodePartial(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+	type result struct {
+		Summary string   `json:"summary"`
+		Score   int      `json:"score"`
+		Done    bool     `json:"done"`
+		Tags    []string `json:"tags"`
+		Items   []item   `json:"items"`
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    result
+		wantErr bool
+	}{
+		{"empty object", `{`, result{}, false},
+		{"partial key", `{"summ`, result{}, false},
+		{"key without value", `{"summary":`, result{}, false},
+		{"partial string", `{"summary":"It wo`, result{Summary: "It wo"}, false},
+		{"partial escape", `{"summary":"a\`, result{Summary: "a"}, false},
+		{"complete member", `{"summary":"It works",`, result{Summary: "It works"}, false},
+		{"number", `{"summary":"x","score":4`, result{Summary: "x", Score: 4}, false},
+		{"partial literal", `{"score":1,"done":tr`, result{Score: 1}, false},
+		{"partial array", `{"tags":["go","te`, result{Tags: []string{"go", "te"}}, false},
+		{"nested objects", `{"items":[{"name":"a"},{"na`, result{Items: []item{{Name: "a"}, {}}}, false},
+		{"complete", `{"summary":"ok","done":true}`, result{Summary: "ok", Done: true}, false},
+		{"garbage", `not json`, result{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got result
+			err := decodePartial(tt.input, &got)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
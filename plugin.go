@@ -0,0 +1,128 @@
+package openrouter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PDFEngine https://openrouter.ai/docs/features/multimodal/pdfs#pdf-processing-engines
+type PDFEngine string
+
+const (
+	// PDFEnginePDFText extracts the text of PDFs, for free.
+	PDFEnginePDFText PDFEngine = "pdf-text"
+	// PDFEngineMistralOCR runs OCR on PDFs, for scanned documents and images.
+	PDFEngineMistralOCR PDFEngine = "mistral-ocr"
+	// PDFEngineNative sends PDFs as is, to models supporting them natively.
+	PDFEngineNative PDFEngine = "native"
+)
+
+// WebEngine https://openrouter.ai/docs/features/web-search#engine-selection
+type WebEngine string
+
+const (
+	// WebEngineNative uses the built-in search of the provider, when it has one.
+	WebEngineNative WebEngine = "native"
+	// WebEngineExa searches the web through Exa.
+	WebEngineExa WebEngine = "exa"
+)
+
+// WebSearch configures the web plugin. Zero fields keep the OpenRouter defaults.
+type WebSearch struct {
+	Engine WebEngine
+	// MaxResults is the number of search results added to the prompt.
+	MaxResults int
+	// SearchPrompt introduces the search results in the prompt.
+	SearchPrompt string
+}
+
+// SearchContextSize is how much search context native web search retrieves.
+type SearchContextSize string
+
+const (
+	SearchContextSizeLow    SearchContextSize = "low"
+	SearchContextSizeMedium SearchContextSize = "medium"
+	SearchContextSizeHigh   SearchContextSize = "high"
+)
+
+type webSearchOptions struct {
+	SearchContextSize SearchContextSize `json:"search_context_size,omitempty"`
+}
+
+type pdfConfig struct {
+	Engine PDFEngine `json:"engine"`
+}
+
+// plugin https://openrouter.ai/docs/api/reference/overview#plugins
+type plugin struct {
+	ID  string     `json:"id"`
+	PDF *pdfConfig `json:"pdf,omitempty"`
+
+	Engine       WebEngine `json:"engine,omitempty"`
+	MaxResults   int       `json:"max_results,omitempty"`
+	SearchPrompt string    `json:"search_prompt,omitempty"`
+}
+
+func (p plugin) validate() error {
+	if p.PDF != nil {
+		switch p.PDF.Engine {
+		case PDFEnginePDFText, PDFEngineMistralOCR, PDFEngineNative:
+		default:
+			return fmt.Errorf("unsupported PDF engine: %s", p.PDF.Engine)
+		}
+	}
+
+	switch p.Engine {
+	case "", WebEngineNative, WebEngineExa:
+	default:
+		return fmt.Errorf("unsupported web search engine: %s", p.Engine)
+	}
+
+	if p.MaxResults < 0 {
+		return errors.New("web search max results cannot be negative")
+	}
+
+	return nil
+}
+
+// Annotation is metadata attached by OpenRouter to an assistant message.
+type Annotation struct {
+	Type string `json:"type"`
+	// File is set on file annotations, holding the parsed content of a file sent in
+	// the conversation. Sending it back on follow-up turns, as AssistantMessage does,
+	// spares parsing the file again.
+	File *FileAnnotation `json:"file,omitempty"`
+	// URLCitation is set on url_citation annotations, citing a web page the
+	// answer is grounded on.
+	URLCitation *URLCitation `json:"url_citation,omitempty"`
+}
+
+// URLCitation is a web source cited by the model. StartIndex and EndIndex locate
+// the cited passage in the content of the message.
+type URLCitation struct {
+	URL        string `json:"url"`
+	Title      string `json:"title,omitempty"`
+	Content    string `json:"content,omitempty"`
+	StartIndex int    `json:"start_index"`
+	EndIndex   int    `json:"end_index"`
+}
+
+// citations returns the URL citations among annotations.
+func citations(annotations []Annotation) []URLCitation {
+	var urls []URLCitation
+	for _, a := range annotations {
+		if a.URLCitation != nil {
+			urls = append(urls, *a.URLCitation)
+		}
+	}
+
+	return urls
+}
+
+// FileAnnotation holds the parsed content of a file.
+type FileAnnotation struct {
+	Hash string `json:"hash"`
+	Name string `json:"name"`
+	// Content is the parsed content, kept as is to be sent back unmodified.
+	Content []map[string]any `json:"content,omitempty"`
+}
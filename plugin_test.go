@@ -0,0 +1,120 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_WithWebSearch(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `"openai/gpt-5.2:online"`, string(field(t, string(body), "model")))
+		assert.JSONEq(t, `[
+			{"id":"file-parser","pdf":{"engine":"pdf-text"}},
+			{"id":"web","engine":"exa","max_results":3,"search_prompt":"Sources:"}
+		]`, string(field(t, string(body), "plugins")))
+		assert.JSONEq(t, `{"search_context_size":"high"}`, string(field(t, string(body), "web_search_options")))
+
+		_, _ = w.Write([]byte(`{"choices":[{"message":{
+			"content":"{\"answer\":\"Tokyo\"}",
+			"annotations":[
+				{"type":"file","file":{"hash":"abc","name":"notes.pdf"}},
+				{"type":"url_citation","url_citation":{"url":"https://example.com/tokyo","title":"Tokyo","content":"Tokyo is the most populated city.","start_index":11,"end_index":16}}
+			]
+		}}]}`))
+	}))
+	defer server.Close()
+
+	res, err := ChatCompletion[testResponse]().
+		Use(Model(ModelChatGpt5_2).Online()).
+		WithFileParser(PDFEnginePDFText).
+		WithWebSearch(WebSearch{Engine: WebEngineExa, MaxResults: 3, SearchPrompt: "Sources:"}).
+		WithWebSearchContextSize(SearchContextSizeHigh).
+		GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &testResponse{Answer: "Tokyo"}, res.Value)
+	assert.Equal(t, []URLCitation{{
+		URL:        "https://example.com/tokyo",
+		Title:      "Tokyo",
+		Content:    "Tokyo is the most populated city.",
+		StartIndex: 11,
+		EndIndex:   16,
+	}}, res.Citations())
+	assert.Len(t, res.Message().Annotations, 2)
+
+	t.Run("defaults", func(t *testing.T) {
+		data, err := json.Marshal(ChatCompletion[testResponse]().WithWebSearch(WebSearch{}))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":"web"}]`, string(field(t, string(data), "plugins")))
+		assert.NotContains(t, string(data), "web_search_options")
+	})
+
+	t.Run("replaces the web plugin", func(t *testing.T) {
+		data, err := json.Marshal(ChatCompletion[testResponse]().
+			WithWebSearch(WebSearch{MaxResults: 3}).
+			WithWebSearch(WebSearch{Engine: WebEngineNative}))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":"web","engine":"native"}]`, string(field(t, string(data), "plugins")))
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			search  WebSearch
+			wantErr string
+		}{
+			{WebSearch{Engine: WebEngineExa, MaxResults: 10}, ""},
+			{WebSearch{MaxResults: -1}, "web search max results cannot be negative"},
+			{WebSearch{Engine: "bing"}, "unsupported web search engine: bing"},
+		}
+
+		for _, tt := range tests {
+			err := ChatCompletion[testResponse]().WithWebSearch(tt.search).Validate()
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				continue
+			}
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.ErrorContains(t, err, tt.wantErr)
+		}
+	})
+
+	t.Run("streamed citations", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"answer\\\":\\\"Tokyo\\\"}\"}}]}\n\n" +
+				"data: {\"choices\":[{\"delta\":{\"annotations\":[{\"type\":\"url_citation\",\"url_citation\":{\"url\":\"https://example.com/tokyo\"}}]},\"finish_reason\":\"stop\"}]}\n\n" +
+				"data: [DONE]\n\n"))
+		}))
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().
+			Use(Model(ModelChatGpt5_2).Online()).
+			Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		for stream.Next() {
+		}
+		res, err := stream.Response()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []URLCitation{{URL: "https://example.com/tokyo"}}, res.Citations())
+	})
+}
+
+func TestModel_Online(t *testing.T) {
+	assert.Equal(t, Model("openai/gpt-5.2:online"), Model(ModelChatGpt5_2).Online())
+	assert.Equal(t, Model("openai/gpt-5.2:online"), Model(ModelChatGpt5_2).Online().Online())
+}
@@ -0,0 +1,105 @@
+package openrouter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ProviderSort https://openrouter.ai/docs/features/provider-routing#provider-sorting
+type ProviderSort string
+
+const (
+	ProviderSortPrice      ProviderSort = "price"
+	ProviderSortThroughput ProviderSort = "throughput"
+	ProviderSortLatency    ProviderSort = "latency"
+)
+
+// DataCollection tells whether providers that may store or train on the data are allowed.
+type DataCollection string
+
+const (
+	DataCollectionAllow DataCollection = "allow"
+	DataCollectionDeny  DataCollection = "deny"
+)
+
+// Quantization https://openrouter.ai/docs/features/provider-routing#quantization
+type Quantization string
+
+const (
+	QuantizationInt4    Quantization = "int4"
+	QuantizationInt8    Quantization = "int8"
+	QuantizationFP4     Quantization = "fp4"
+	QuantizationFP6     Quantization = "fp6"
+	QuantizationFP8     Quantization = "fp8"
+	QuantizationFP16    Quantization = "fp16"
+	QuantizationBF16    Quantization = "bf16"
+	QuantizationFP32    Quantization = "fp32"
+	QuantizationUnknown Quantization = "unknown"
+)
+
+var quantizations = []Quantization{
+	QuantizationInt4, QuantizationInt8, QuantizationFP4, QuantizationFP6, QuantizationFP8,
+	QuantizationFP16, QuantizationBF16, QuantizationFP32, QuantizationUnknown,
+}
+
+// MaxPrice caps the price of the providers serving a request, in USD per million
+// tokens for prompt and completion, and per unit for requests and images.
+type MaxPrice struct {
+	Prompt     float64 `json:"prompt,omitempty"`
+	Completion float64 `json:"completion,omitempty"`
+	Request    float64 `json:"request,omitempty"`
+	Image      float64 `json:"image,omitempty"`
+}
+
+// providerPreferences https://openrouter.ai/docs/features/provider-routing
+type providerPreferences struct {
+	Order             []string       `json:"order,omitempty"`
+	Only              []string       `json:"only,omitempty"`
+	Ignore            []string       `json:"ignore,omitempty"`
+	AllowFallbacks    *bool          `json:"allow_fallbacks,omitempty"`
+	RequireParameters *bool          `json:"require_parameters,omitempty"`
+	DataCollection    DataCollection `json:"data_collection,omitempty"`
+	ZDR               *bool          `json:"zdr,omitempty"`
+	Sort              ProviderSort   `json:"sort,omitempty"`
+	Quantizations     []Quantization `json:"quantizations,omitempty"`
+	MaxPrice          *MaxPrice      `json:"max_price,omitempty"`
+}
+
+func (p *providerPreferences) validate() error {
+	for _, providers := range [][]string{p.Order, p.Only, p.Ignore} {
+		if slices.Contains(providers, "") {
+			return errors.New("provider names cannot be empty")
+		}
+	}
+
+	for _, provider := range p.Only {
+		if slices.Contains(p.Ignore, provider) {
+			return fmt.Errorf("provider %s is both required and ignored", provider)
+		}
+	}
+
+	switch p.DataCollection {
+	case "", DataCollectionAllow, DataCollectionDeny:
+	default:
+		return fmt.Errorf("unsupported data collection policy: %s", p.DataCollection)
+	}
+
+	switch p.Sort {
+	case "", ProviderSortPrice, ProviderSortThroughput, ProviderSortLatency:
+	default:
+		return fmt.Errorf("unsupported provider sort: %s", p.Sort)
+	}
+
+	for _, q := range p.Quantizations {
+		if !slices.Contains(quantizations, q) {
+			return fmt.Errorf("unsupported quantization: %s", q)
+		}
+	}
+
+	if m := p.MaxPrice; m != nil && (m.Prompt < 0 || m.Completion < 0 || m.Request < 0 || m.Image < 0) {
+		return errors.New("max price cannot be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_Provider(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	req := ChatCompletion[testResponse]().
+		Use(ModelClaudeSonnet4_5).
+		WithProviderOrder("anthropic", "amazon-bedrock").
+		WithIgnoredProviders("google-vertex").
+		WithAllowFallbacks(false).
+		WithRequireParameters(true).
+		WithDataCollection(DataCollectionDeny).
+		WithZDR(true).
+		WithProviderSort(ProviderSortThroughput).
+		WithQuantizations(QuantizationFP8, QuantizationBF16).
+		WithMaxPrice(MaxPrice{Prompt: 3, Completion: 15})
+
+	assert.NoError(t, req.Validate())
+
+	data, err := json.Marshal(req)
+	assert.NoError(t, err)
+
+	var body struct {
+		Provider json.RawMessage `json:"provider"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &body))
+	assert.JSONEq(t, `{
+		"order": ["anthropic", "amazon-bedrock"],
+		"ignore": ["google-vertex"],
+		"allow_fallbacks": false,
+		"require_parameters": true,
+		"data_collection": "deny",
+		"zdr": true,
+		"sort": "throughput",
+		"quantizations": ["fp8", "bf16"],
+		"max_price": {"prompt": 3, "completion": 15}
+	}`, string(body.Provider))
+
+	t.Run("omitted by default", func(t *testing.T) {
+		data, err := json.Marshal(ChatCompletion[testResponse]())
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), `"provider"`)
+	})
+}
+
+func TestChatCompletionRequest_Validate_Provider(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	tests := []struct {
+		name    string
+		req     *ChatCompletionRequest[testResponse]
+		wantErr string
+	}{
+		{"empty provider", ChatCompletion[testResponse]().WithProviderOrder("openai", ""), "provider names cannot be empty"},
+		{"only and ignore", ChatCompletion[testResponse]().WithOnlyProviders("openai").WithIgnoredProviders("openai"), "provider openai is both required and ignored"},
+		{"data collection", ChatCompletion[testResponse]().WithDataCollection("sometimes"), "unsupported data collection policy: sometimes"},
+		{"sort", ChatCompletion[testResponse]().WithProviderSort("popularity"), "unsupported provider sort: popularity"},
+		{"quantization", ChatCompletion[testResponse]().WithQuantizations("int2"), "unsupported quantization: int2"},
+		{"max price", ChatCompletion[testResponse]().WithMaxPrice(MaxPrice{Prompt: -1}), "max price cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("not sent when invalid", func(t *testing.T) {
+		client := NewClient(WithHTTPClient(&http.Client{Transport: &MockTransport{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("the request must not be sent")
+			return nil, nil
+		}}}))
+
+		_, err := ChatCompletion[testResponse]().WithProviderSort("popularity").GenerateContentWith(client)
+
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+	})
+}
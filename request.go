@@ -17,8 +17,8 @@ type responseFormat struct {
 	JsonSchema jsonSchema `json:"json_schema"`
 }
 
-func newJSONResponseFormat(schema *schema.Schema) responseFormat {
-	return responseFormat{
+func newJSONResponseFormat(schema *schema.Schema) *responseFormat {
+	return &responseFormat{
 		Type: "json_schema",
 		JsonSchema: jsonSchema{
 			Name:   "response",
@@ -29,10 +29,23 @@ func newJSONResponseFormat(schema *schema.Schema) responseFormat {
 }
 
 type openRouterChatCompletionRequest struct {
-	Model          Model            `json:"model"`
-	Messages       []Message        `json:"messages"`
-	ResponseFormat responseFormat   `json:"response_format"`
-	Reasoning      *reasoningConfig `json:"reasoning,omitempty"`
+	Model          Model                `json:"model"`
+	Models         []Model              `json:"models,omitempty"`
+	Messages       []Message            `json:"messages"`
+	ResponseFormat *responseFormat      `json:"response_format,omitempty"`
+	Reasoning      *reasoningConfig     `json:"reasoning,omitempty"`
+	Stream         bool                 `json:"stream,omitempty"`
+	Provider       *providerPreferences `json:"provider,omitempty"`
+	Plugins        []plugin             `json:"plugins,omitempty"`
+	Modalities     []Modality           `json:"modalities,omitempty"`
+
+	WebSearchOptions *webSearchOptions `json:"web_search_options,omitempty"`
+
+	samplingParameters
+
+	Tools             []toolDefinition `json:"tools,omitempty"`
+	ToolChoice        *ToolChoice      `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool            `json:"parallel_tool_calls,omitempty"`
 }
 
 func (o *openRouterChatCompletionRequest) SetReasoningEffort(value ReasoningEffort) {
@@ -0,0 +1,216 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FinishReason tells why the model stopped generating, normalized by OpenRouter across providers.
+type FinishReason string
+
+const (
+	FinishReasonStop          FinishReason = "stop"
+	FinishReasonLength        FinishReason = "length"
+	FinishReasonToolCalls     FinishReason = "tool_calls"
+	FinishReasonContentFilter FinishReason = "content_filter"
+	FinishReasonError         FinishReason = "error"
+)
+
+// Usage reports the tokens consumed by a completion.
+type Usage struct {
+	PromptTokens            int                      `json:"prompt_tokens"`
+	CompletionTokens        int                      `json:"completion_tokens"`
+	TotalTokens             int                      `json:"total_tokens"`
+	Cost                    float64                  `json:"cost,omitempty"`
+	PromptTokensDetails     *PromptTokensDetails     `json:"prompt_tokens_details,omitempty"`
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+// CachedTokens returns the prompt tokens read from the prompt cache.
+func (u Usage) CachedTokens() int {
+	if u.PromptTokensDetails == nil {
+		return 0
+	}
+
+	return u.PromptTokensDetails.CachedTokens
+}
+
+// PromptTokensDetails breaks down the prompt tokens.
+type PromptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+// CompletionTokensDetails breaks down the completion tokens.
+type CompletionTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+// Response is the full result of a chat completion: the decoded value along
+// with the metadata OpenRouter returns about the generation.
+type Response[T any] struct {
+	// ID identifies the generation on OpenRouter.
+	ID string
+	// Model is the model that actually served the completion.
+	Model Model
+	// RequestedModel is the primary model of the request.
+	RequestedModel Model
+	// Fallbacks lists the fallback models of the request, in order.
+	Fallbacks []Model
+	// Provider is the upstream provider that served the completion.
+	Provider string
+	// Created is the Unix timestamp of the generation.
+	Created int64
+	// FinishReason is the normalized reason the generation stopped.
+	FinishReason FinishReason
+	// NativeFinishReason is the reason as reported by the provider.
+	NativeFinishReason string
+	Usage              Usage
+	// Content is the raw text returned by the model.
+	Content string
+	// ToolCalls lists the tools the model asked to call.
+	ToolCalls []ToolCall
+	// Refusal is set when the model declined to answer.
+	Refusal          string
+	Reasoning        string
+	ReasoningDetails []ReasoningDetail
+	Annotations      []Annotation
+	// Images lists the images generated by the model, when asked for with WithModalities.
+	Images []GeneratedImage
+	// Value is Content decoded into T. It is nil when the model called tools, refused
+	// or generated images along with text that is not valid JSON, if any.
+	Value *T
+	// Generation holds the stats of the completion when the client was created with
+	// WithGenerationStats. GenerationErr tells why they could not be fetched.
+	Generation    *Generation
+	GenerationErr error
+}
+
+type chatCompletionMessage struct {
+	Content          string               `json:"content"`
+	ToolCalls        []ToolCall           `json:"tool_calls"`
+	Refusal          string               `json:"refusal"`
+	Reasoning        string               `json:"reasoning"`
+	ReasoningDetails []ReasoningDetail    `json:"reasoning_details"`
+	Annotations      []Annotation         `json:"annotations"`
+	Images           []generatedImagePart `json:"images"`
+}
+
+type chatCompletionChoice struct {
+	Message            chatCompletionMessage `json:"message"`
+	FinishReason       FinishReason          `json:"finish_reason"`
+	NativeFinishReason string                `json:"native_finish_reason"`
+}
+
+type chatCompletionResponse struct {
+	ID       string                 `json:"id"`
+	Model    Model                  `json:"model"`
+	Provider string                 `json:"provider"`
+	Created  int64                  `json:"created"`
+	Choices  []chatCompletionChoice `json:"choices"`
+	Usage    *Usage                 `json:"usage"`
+}
+
+func newResponse[T any](result chatCompletionResponse) (*Response[T], error) {
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("unexpected error: empty response")
+	}
+	choice := result.Choices[0]
+
+	res := &Response[T]{
+		ID:                 result.ID,
+		Model:              result.Model,
+		Provider:           result.Provider,
+		Created:            result.Created,
+		FinishReason:       choice.FinishReason,
+		NativeFinishReason: choice.NativeFinishReason,
+		Content:            choice.Message.Content,
+		ToolCalls:          choice.Message.ToolCalls,
+		Refusal:            choice.Message.Refusal,
+		Reasoning:          choice.Message.Reasoning,
+		ReasoningDetails:   choice.Message.ReasoningDetails,
+		Annotations:        choice.Message.Annotations,
+	}
+	if result.Usage != nil {
+		res.Usage = *result.Usage
+	}
+
+	images, err := newGeneratedImages(choice.Message.Images)
+	if err != nil {
+		return nil, err
+	}
+	res.Images = images
+
+	// Text sent along tool calls, e.g. "Let me check the weather.", is not the answer.
+	if len(res.ToolCalls) > 0 || res.Content == "" && (res.Refusal != "" || len(res.Images) > 0) {
+		return res, nil
+	}
+
+	var t T
+	if err := json.Unmarshal([]byte(res.Content), &t); err != nil {
+		if len(res.Images) > 0 {
+			// Image generation is not constrained by the schema: keep the images
+			// along with the plain text.
+			return res, nil
+		}
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
+	}
+	res.Value = &t
+
+	return res, nil
+}
+
+// Message returns the assistant message to append to the conversation to continue it.
+func (r *Response[T]) Message() AssistantMessage {
+	return AssistantMessage{
+		Content:          r.Content,
+		ToolCalls:        r.ToolCalls,
+		Refusal:          r.Refusal,
+		Reasoning:        r.Reasoning,
+		ReasoningDetails: r.ReasoningDetails,
+		Annotations:      r.Annotations,
+	}
+}
+
+// Citations returns the web sources the answer is grounded on, from its url_citation annotations.
+func (r *Response[T]) Citations() []URLCitation {
+	return citations(r.Annotations)
+}
+
+// UsedFallback reports whether the completion was served by one of the fallback
+// models of the request rather than the requested one. Variants such as :online
+// are ignored, and dated versions such as openai/gpt-5.2-2025-12-11 count as the
+// model they are a version of.
+func (r *Response[T]) UsedFallback() bool {
+	if len(r.Fallbacks) == 0 || r.Model == "" {
+		return false
+	}
+
+	served := r.Model.base()
+	match, length := -1, 0
+	for i, m := range append([]Model{r.RequestedModel}, r.Fallbacks...) {
+		m = m.base()
+		switch {
+		case served == m:
+			return i > 0
+		case strings.HasPrefix(string(served), string(m)+"-") && len(m) > length:
+			match, length = i, len(m)
+		}
+	}
+
+	return match > 0
+}
+
+// missingValueError explains why the response holds no value.
+func (r *Response[T]) missingValueError() error {
+	switch {
+	case r.Refusal != "":
+		return fmt.Errorf("unexpected error: the model refused to answer: %s", r.Refusal)
+	case len(r.ToolCalls) > 0:
+		return fmt.Errorf("unexpected error: the model called tools instead of answering")
+	case len(r.Images) > 0:
+		return fmt.Errorf("unexpected error: the model generated images without a JSON answer")
+	default:
+		return fmt.Errorf("unexpected error: empty response")
+	}
+}
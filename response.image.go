@@ -0,0 +1,81 @@
+package openrouter
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// GeneratedImage is an image produced by a model asked for image outputs with
+// WithModalities.
+type GeneratedImage struct {
+	MIMEType string
+	Data     []byte
+}
+
+type generatedImagePart struct {
+	Type     string `json:"type"`
+	ImageURL struct {
+		URL string `json:"url"`
+	} `json:"image_url"`
+}
+
+func newGeneratedImages(parts []generatedImagePart) ([]GeneratedImage, error) {
+	var images []GeneratedImage
+	for _, part := range parts {
+		mimeType, data, err := parseDataURL(part.ImageURL.URL)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding generated image: %w", err)
+		}
+		images = append(images, GeneratedImage{MIMEType: mimeType, Data: data})
+	}
+
+	return images, nil
+}
+
+// Extension returns the usual file extension of the image type, such as ".png",
+// or an empty string when the type is unknown.
+func (i GeneratedImage) Extension() string {
+	switch i.MIMEType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	}
+
+	if extensions, err := mime.ExtensionsByType(i.MIMEType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+
+	return ""
+}
+
+// WriteFile writes the image to the named file.
+func (i GeneratedImage) WriteFile(name string) error {
+	if err := os.WriteFile(name, i.Data, 0o644); err != nil {
+		return fmt.Errorf("error writing image: %w", err)
+	}
+
+	return nil
+}
+
+// WriteImages writes the generated images to dir, named after prefix, their index
+// and the extension of their type, e.g. image-1.png. It returns the written paths.
+func (r *Response[T]) WriteImages(dir, prefix string) ([]string, error) {
+	paths := make([]string, 0, len(r.Images))
+	for n, image := range r.Images {
+		path := filepath.Join(dir, prefix+"-"+strconv.Itoa(n+1)+image.Extension())
+		if err := image.WriteFile(path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
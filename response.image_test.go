@@ -0,0 +1,175 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_WithModalities(t *testing.T) {
+	type testResponse struct {
+		Caption string `json:"caption"`
+	}
+
+	image := `{"type":"image_url","image_url":{"url":"` + dataURL("image/png", pngImage) + `"}}`
+
+	tests := []struct {
+		name       string
+		message    string
+		wantImages []GeneratedImage
+		wantValue  *testResponse
+		wantErr    string
+	}{
+		{
+			name:       "images and text",
+			message:    `{"content":"{\"caption\":\"A cat\"}","images":[` + image + `]}`,
+			wantImages: []GeneratedImage{{MIMEType: "image/png", Data: pngImage}},
+			wantValue:  &testResponse{Caption: "A cat"},
+		},
+		{
+			name:       "images only",
+			message:    `{"content":"","images":[` + image + `,` + image + `]}`,
+			wantImages: []GeneratedImage{{MIMEType: "image/png", Data: pngImage}, {MIMEType: "image/png", Data: pngImage}},
+		},
+		{
+			name:       "images and plain text",
+			message:    `{"content":"Here is your image","images":[` + image + `]}`,
+			wantImages: []GeneratedImage{{MIMEType: "image/png", Data: pngImage}},
+		},
+		{
+			name:    "plain text without images",
+			message: `{"content":"Here is your image"}`,
+			wantErr: "error unmarshaling response",
+		},
+		{
+			name:    "not a data URL",
+			message: `{"content":"","images":[{"type":"image_url","image_url":{"url":"https://example.com/cat.png"}}]}`,
+			wantErr: "error decoding generated image: unsupported format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, `["image","text"]`, string(field(t, string(body), "modalities")))
+				assert.Nil(t, field(t, string(body), "response_format"), "image models do not follow JSON schemas")
+				_, _ = w.Write([]byte(`{"choices":[{"message":` + tt.message + `}]}`))
+			}))
+			defer server.Close()
+
+			res, err := ChatCompletion[testResponse]().
+				Use(ModelGemini2_5FlashLite).
+				WithModalities(ModalityImage, ModalityText).
+				GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantImages, res.Images)
+			assert.Equal(t, tt.wantValue, res.Value)
+		})
+	}
+
+	t.Run("capability checks", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/models" {
+				_, _ = w.Write([]byte(`{"data":[{
+					"id":"google/gemini-2.5-flash-image",
+					"architecture":{"input_modalities":["text","image"],"output_modalities":["image","text"]},
+					"supported_parameters":["max_tokens","temperature"]
+				}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"A cat","images":[` + image + `]}}]}`))
+		}))
+		defer server.Close()
+		client := NewClient(WithBaseURL(server.URL), WithCapabilityChecks(time.Hour))
+
+		res, err := ChatCompletion[testResponse]().
+			Use("google/gemini-2.5-flash-image").
+			WithModalities(ModalityImage, ModalityText).
+			GenerateResponse(context.Background(), client)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "A cat", res.Content)
+		assert.Len(t, res.Images, 1)
+
+		_, err = ChatCompletion[testResponse]().
+			Use("google/gemini-2.5-flash-image").
+			GenerateResponse(context.Background(), client)
+		assert.ErrorContains(t, err, "does not support structured outputs")
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"images\":[" + image + "]}}]}\n\n" +
+				"data: {\"choices\":[{\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n" +
+				"data: [DONE]\n\n"))
+		}))
+		defer server.Close()
+
+		stream, err := ChatCompletion[testResponse]().
+			Use(ModelGemini2_5FlashLite).
+			WithModalities(ModalityImage).
+			Stream(context.Background(), NewClient(WithBaseURL(server.URL)))
+		assert.NoError(t, err)
+		defer stream.Close()
+
+		for stream.Next() {
+		}
+		res, err := stream.Response()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []GeneratedImage{{MIMEType: "image/png", Data: pngImage}}, res.Images)
+		assert.Nil(t, res.Value)
+	})
+}
+
+func TestResponse_WriteImages(t *testing.T) {
+	dir := t.TempDir()
+	res := Response[struct{}]{Images: []GeneratedImage{
+		{MIMEType: "image/png", Data: pngImage},
+		{MIMEType: "image/jpeg", Data: jpegImage},
+	}}
+
+	paths, err := res.WriteImages(dir, "cat")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "cat-1.png"), filepath.Join(dir, "cat-2.jpg")}, paths)
+	for n, path := range paths {
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, res.Images[n].Data, data)
+	}
+
+	_, err = res.WriteImages(filepath.Join(dir, "missing"), "cat")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParseDataURL(t *testing.T) {
+	mimeType, data, err := parseDataURL("data:image/webp;base64," + base64.StdEncoding.EncodeToString(webpImage))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/webp", mimeType)
+	assert.Equal(t, webpImage, data)
+
+	for _, url := range []string{"https://example.com/cat.png", "data:text/plain,hello", "data:image/png;base64"} {
+		_, _, err := parseDataURL(url)
+		assert.ErrorIs(t, err, ErrUnsupportedFormat, url)
+	}
+
+	_, _, err = parseDataURL("data:image/png;base64,!!!")
+	assert.ErrorContains(t, err, "error decoding data URL")
+}
@@ -0,0 +1,212 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries failed calls. The zero value disables retries.
+//
+// Idempotent calls, such as GET and DELETE, are retried on RetryableStatusCodes and on
+// transient network errors. Chat completions and embeddings are not processed nor
+// billed when they fail with an error status, so they are retried on
+// RetryableStatusCodes too, but only on network errors raised before the request was
+// sent. Calls with durable side effects, such as creating or updating keys, are only
+// retried on 429 responses and on network errors raised before the request was sent,
+// unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed backoff. Retry-After values sent by the API are honored as is.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff between consecutive retries. Defaults to 2.
+	Multiplier float64
+	// Jitter randomizes each backoff by up to this fraction, between 0 and 1.
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP statuses worth retrying.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent retries every call like idempotent ones, at the risk of
+	// duplicate side effects, e.g. a key created twice.
+	RetryNonIdempotent bool
+	// OnAttempt, when set, is called after every failed attempt.
+	OnAttempt func(Attempt)
+}
+
+// Attempt describes a failed call, as reported to RetryPolicy.OnAttempt.
+type Attempt struct {
+	// Number is the 1-based index of the attempt.
+	Number int
+	// StatusCode is the HTTP status received, or 0 on network errors.
+	StatusCode int
+	// Err is the error the attempt failed with.
+	Err error
+	// Retrying tells whether another attempt follows.
+	Retrying bool
+	// Delay is the wait before the next attempt.
+	Delay time.Duration
+}
+
+// DefaultRetryPolicy retries rate limits, timeouts, server errors and network errors
+// up to four attempts, starting at 500ms and doubling up to 30s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy makes the client retry failed calls according to policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// shouldRetry tells whether a call to path with the given method failing with resp
+// and err deserves another attempt.
+func (p RetryPolicy) shouldRetry(method, path string, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	switch {
+	case p.RetryNonIdempotent || isIdempotent(method):
+		if resp != nil {
+			return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+		}
+		return isTransientError(err)
+	case isGeneration(path):
+		if resp != nil {
+			return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+		}
+		return isUnsentError(err)
+	default:
+		if resp != nil {
+			return resp.StatusCode == http.StatusTooManyRequests && slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+		}
+		return isUnsentError(err)
+	}
+}
+
+// isGeneration reports calls that are neither processed nor billed when they fail
+// with an error status.
+func isGeneration(path string) bool {
+	return path == "/chat/completions" || path == "/embeddings"
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// delay computes the wait before the attempt following the given one.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * min(p.Jitter, 1) * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(d)
+}
+
+func (p RetryPolicy) observe(attempt Attempt) {
+	if p.OnAttempt != nil {
+		p.OnAttempt(attempt)
+	}
+}
+
+// parseRetryAfter reads a Retry-After header holding either seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// isTransientError reports network errors that may succeed on a new attempt.
+func isTransientError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isUnsentError reports network errors raised before the request was sent, such
+// as failed DNS lookups and refused connections.
+func isUnsentError(err error) bool {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	return errors.As(err, &dnsErr) ||
+		errors.As(err, &opErr) && opErr.Op == "dial" ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,285 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Retry(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+
+	t.Run("retries retryable statuses", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch calls.Add(1) {
+			case 1:
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":{"code":429,"message":"Rate limited"}}`))
+			case 2:
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte(`{"error":{"code":502,"message":"Provider down"}}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":{"total_credits":10,"total_usage":4}}`))
+			}
+		}))
+		defer server.Close()
+
+		var attempts []Attempt
+		p := policy
+		p.OnAttempt = func(a Attempt) { attempts = append(attempts, a) }
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(p))
+
+		res, err := client.GetCredits(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Credits{TotalCredits: 10, TotalUsage: 4}, res)
+		assert.EqualValues(t, 3, calls.Load())
+		if assert.Len(t, attempts, 2) {
+			assert.Equal(t, 1, attempts[0].Number)
+			assert.Equal(t, http.StatusTooManyRequests, attempts[0].StatusCode)
+			assert.True(t, attempts[0].Retrying)
+			assert.Zero(t, attempts[0].Delay)
+			assert.Equal(t, http.StatusBadGateway, attempts[1].StatusCode)
+			assert.ErrorContains(t, attempts[1].Err, "Provider down")
+		}
+	})
+
+	t.Run("retries chat completions failing with retryable statuses", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"model"`)
+
+			switch calls.Add(1) {
+			case 1:
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":{"code":429,"message":"Rate limited"}}`))
+			case 2:
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte(`{"error":{"code":502,"message":"Provider down"}}`))
+			default:
+				_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`))
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+		res, err := ChatCompletion[testResponse]().Use(ModelGemini2_5FlashLite).GenerateContentWith(client)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &testResponse{Answer: "ok"}, res)
+		assert.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("retries calls with side effects only when not processed", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch calls.Add(1) {
+			case 1:
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"error":{"code":429,"message":"Rate limited"}}`))
+			case 2:
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte(`{"error":{"code":502,"message":"Provider down"}}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":{"hash":"abc"},"key":"sk-or-v1-secret"}`))
+			}
+		}))
+		defer server.Close()
+
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+		_, err := client.CreateKey(context.Background(), CreateKeyParams{Name: "tenant"})
+
+		assert.ErrorContains(t, err, "API error: 502 - Provider down")
+		assert.EqualValues(t, 2, calls.Load())
+
+		t.Run("unless allowed", func(t *testing.T) {
+			calls.Store(0)
+			p := policy
+			p.RetryNonIdempotent = true
+			client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(p))
+
+			key, err := client.CreateKey(context.Background(), CreateKeyParams{Name: "tenant"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "sk-or-v1-secret", key.Secret)
+			assert.EqualValues(t, 3, calls.Load())
+		})
+	})
+
+	t.Run("does not retry other statuses", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"code":400,"message":"Bad request"}}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+		_, err := ChatCompletion[testResponse]().GenerateContentWith(client)
+
+		assert.ErrorContains(t, err, "API error: 400 - Bad request")
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"code":503,"message":"Unavailable"}}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+		_, err := client.GetCredits(context.Background())
+
+		assert.ErrorContains(t, err, "API error: 503 - Unavailable")
+		assert.EqualValues(t, policy.MaxAttempts, calls.Load())
+	})
+
+	t.Run("retries network errors", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			err       error
+			call      func(*Client) error
+			wantCalls int32
+		}{
+			{"idempotent", io.ErrUnexpectedEOF, getCredits, 2},
+			{"completion interrupted", io.ErrUnexpectedEOF, createCompletion, 1},
+			{"completion refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, createCompletion, 2},
+			{"completion dns failure", &net.DNSError{Err: "no such host", Name: "openrouter.ai"}, createCompletion, 2},
+			{"key creation interrupted", syscall.ECONNRESET, createKey, 1},
+			{"key creation refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, createKey, 2},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var calls atomic.Int32
+				transport := &MockTransport{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if calls.Add(1) == 1 {
+						return nil, tt.err
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`{"data":{},"choices":[{"message":{"content":"{\"answer\":\"ok\"}"}}]}`)),
+						Header:     make(http.Header),
+					}, nil
+				}}
+				client := NewClient(WithHTTPClient(&http.Client{Transport: transport}), WithRetryPolicy(policy))
+
+				err := tt.call(client)
+
+				assert.Equal(t, tt.wantCalls, calls.Load())
+				if tt.wantCalls == 1 {
+					assert.ErrorIs(t, err, tt.err)
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+		}
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"code":429,"message":"Rate limited"}}`))
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+		_, err := ChatCompletion[testResponse]().GenerateContentWithContext(ctx, client)
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func getCredits(c *Client) error {
+	_, err := c.GetCredits(context.Background())
+	return err
+}
+
+func createCompletion(c *Client) error {
+	_, err := ChatCompletion[struct{}]().Use(ModelGemini2_5FlashLite).GenerateContentWith(c)
+	return err
+}
+
+func createKey(c *Client) error {
+	_, err := c.CreateKey(context.Background(), CreateKeyParams{Name: "tenant"})
+	return err
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, nil))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3, nil))
+	assert.Equal(t, time.Second, policy.delay(5, nil))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		d := policy.delay(1, nil)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	assert.Equal(t, 3*time.Second, policy.delay(1, resp))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"negative seconds", "-5", 0, true},
+		{"http date", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{"past http date", now.Add(-10 * time.Second).Format(http.TimeFormat), 0, true},
+		{"invalid", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package openrouter
+
+import (
+	"errors"
+	"fmt"
+)
+
+// samplingParameters https://openrouter.ai/docs/api/reference/parameters
+type samplingParameters struct {
+	Temperature       *float64        `json:"temperature,omitempty"`
+	TopP              *float64        `json:"top_p,omitempty"`
+	TopK              *int            `json:"top_k,omitempty"`
+	MinP              *float64        `json:"min_p,omitempty"`
+	TopA              *float64        `json:"top_a,omitempty"`
+	MaxTokens         *int            `json:"max_tokens,omitempty"`
+	Stop              []string        `json:"stop,omitempty"`
+	Seed              *int            `json:"seed,omitempty"`
+	FrequencyPenalty  *float64        `json:"frequency_penalty,omitempty"`
+	PresencePenalty   *float64        `json:"presence_penalty,omitempty"`
+	RepetitionPenalty *float64        `json:"repetition_penalty,omitempty"`
+	LogitBias         map[int]float64 `json:"logit_bias,omitempty"`
+}
+
+func (p samplingParameters) validate() error {
+	ranges := []struct {
+		name     string
+		value    *float64
+		min, max float64
+	}{
+		{"temperature", p.Temperature, 0, 2},
+		{"top_p", p.TopP, 0, 1},
+		{"min_p", p.MinP, 0, 1},
+		{"top_a", p.TopA, 0, 1},
+		{"frequency_penalty", p.FrequencyPenalty, -2, 2},
+		{"presence_penalty", p.PresencePenalty, -2, 2},
+		{"repetition_penalty", p.RepetitionPenalty, 0, 2},
+	}
+	for _, r := range ranges {
+		if r.value != nil && (*r.value < r.min || *r.value > r.max) {
+			return fmt.Errorf("%s must be between %g and %g, got %g", r.name, r.min, r.max, *r.value)
+		}
+	}
+
+	if p.TopK != nil && *p.TopK < 0 {
+		return fmt.Errorf("top_k cannot be negative, got %d", *p.TopK)
+	}
+
+	if p.MaxTokens != nil && *p.MaxTokens < 1 {
+		return fmt.Errorf("max_tokens must be at least 1, got %d", *p.MaxTokens)
+	}
+
+	for _, stop := range p.Stop {
+		if stop == "" {
+			return errors.New("stop sequences cannot be empty")
+		}
+	}
+
+	for token, bias := range p.LogitBias {
+		if bias < -100 || bias > 100 {
+			return fmt.Errorf("logit_bias of token %d must be between -100 and 100, got %g", token, bias)
+		}
+	}
+
+	return nil
+}
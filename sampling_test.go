@@ -0,0 +1,93 @@
+package openrouter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatCompletionRequest_Sampling(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	req := ChatCompletion[testResponse]().
+		Use(ModelGemini2_5FlashLite).
+		WithTemperature(0).
+		WithTopP(0.9).
+		WithTopK(40).
+		WithMinP(0.05).
+		WithTopA(0.2).
+		WithMaxTokens(1024).
+		WithStop("###", "END").
+		WithSeed(42).
+		WithFrequencyPenalty(0.5).
+		WithPresencePenalty(-0.5).
+		WithRepetitionPenalty(1.1).
+		WithLogitBias(map[int]float64{50256: -100})
+
+	assert.NoError(t, req.Validate())
+
+	data, err := json.Marshal(req)
+	assert.NoError(t, err)
+
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, 0.0, body["temperature"])
+	assert.Equal(t, 0.9, body["top_p"])
+	assert.Equal(t, 40.0, body["top_k"])
+	assert.Equal(t, 0.05, body["min_p"])
+	assert.Equal(t, 0.2, body["top_a"])
+	assert.Equal(t, 1024.0, body["max_tokens"])
+	assert.Equal(t, []any{"###", "END"}, body["stop"])
+	assert.Equal(t, 42.0, body["seed"])
+	assert.Equal(t, 0.5, body["frequency_penalty"])
+	assert.Equal(t, -0.5, body["presence_penalty"])
+	assert.Equal(t, 1.1, body["repetition_penalty"])
+	assert.Equal(t, map[string]any{"50256": -100.0}, body["logit_bias"])
+
+	t.Run("omitted by default", func(t *testing.T) {
+		data, err := json.Marshal(ChatCompletion[testResponse]())
+		assert.NoError(t, err)
+
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(data, &body))
+		assert.NotContains(t, body, "temperature")
+		assert.NotContains(t, body, "max_tokens")
+		assert.NotContains(t, body, "logit_bias")
+	})
+}
+
+func TestChatCompletionRequest_Validate_Sampling(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	tests := []struct {
+		name    string
+		req     *ChatCompletionRequest[testResponse]
+		wantErr string
+	}{
+		{"temperature", ChatCompletion[testResponse]().WithTemperature(2.5), "temperature must be between 0 and 2, got 2.5"},
+		{"top_p", ChatCompletion[testResponse]().WithTopP(-0.1), "top_p must be between 0 and 1, got -0.1"},
+		{"top_k", ChatCompletion[testResponse]().WithTopK(-1), "top_k cannot be negative, got -1"},
+		{"min_p", ChatCompletion[testResponse]().WithMinP(1.5), "min_p must be between 0 and 1"},
+		{"top_a", ChatCompletion[testResponse]().WithTopA(2), "top_a must be between 0 and 1"},
+		{"max_tokens", ChatCompletion[testResponse]().WithMaxTokens(0), "max_tokens must be at least 1, got 0"},
+		{"stop", ChatCompletion[testResponse]().WithStop(""), "stop sequences cannot be empty"},
+		{"frequency_penalty", ChatCompletion[testResponse]().WithFrequencyPenalty(3), "frequency_penalty must be between -2 and 2"},
+		{"presence_penalty", ChatCompletion[testResponse]().WithPresencePenalty(-3), "presence_penalty must be between -2 and 2"},
+		{"repetition_penalty", ChatCompletion[testResponse]().WithRepetitionPenalty(-1), "repetition_penalty must be between 0 and 2"},
+		{"logit_bias", ChatCompletion[testResponse]().WithLogitBias(map[int]float64{1: 101}), "logit_bias of token 1 must be between -100 and 100, got 101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
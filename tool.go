@@ -0,0 +1,164 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/orixa-group/open-router/schema"
+)
+
+// Tool is a function the model can ask to call.
+type Tool interface {
+	Name() string
+	Description() string
+	// Parameters returns the JSON schema of the arguments the tool expects.
+	Parameters() (*schema.Schema, error)
+	// Call runs the tool with the JSON arguments sent by the model and returns its result.
+	Call(ctx context.Context, arguments string) (string, error)
+}
+
+// NewTool declares a Go function as a tool. The parameters schema is generated
+// from Args, which must be a struct, and the arguments sent by the model are
+// decoded into it. Results are sent back to the model as JSON, except strings
+// which are sent as is.
+func NewTool[Args, Result any](name, description string, fn func(context.Context, Args) (Result, error)) Tool {
+	return functionTool[Args, Result]{name: name, description: description, fn: fn}
+}
+
+type functionTool[Args, Result any] struct {
+	name        string
+	description string
+	fn          func(context.Context, Args) (Result, error)
+}
+
+func (t functionTool[Args, Result]) Name() string {
+	return t.name
+}
+
+func (t functionTool[Args, Result]) Description() string {
+	return t.description
+}
+
+func (t functionTool[Args, Result]) Parameters() (*schema.Schema, error) {
+	var args Args
+	s, err := schema.Generate(args)
+	if err != nil {
+		return nil, err
+	}
+	if s.Type != schema.Object {
+		return nil, fmt.Errorf("tool arguments must be a struct, got %s", s.Type)
+	}
+
+	return s, nil
+}
+
+func (t functionTool[Args, Result]) Call(ctx context.Context, arguments string) (string, error) {
+	var args Args
+	if arguments != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("error unmarshaling arguments of tool %s: %w", t.name, err)
+		}
+	}
+
+	result, err := t.fn(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	if s, ok := any(result).(string); ok {
+		return s, nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling result of tool %s: %w", t.name, err)
+	}
+
+	return string(data), nil
+}
+
+// ToolChoice controls whether and which tools the model calls.
+type ToolChoice struct {
+	mode     string
+	function string
+}
+
+var (
+	// ToolChoiceAuto lets the model decide whether to call tools.
+	ToolChoiceAuto = ToolChoice{mode: "auto"}
+	// ToolChoiceNone prevents the model from calling tools.
+	ToolChoiceNone = ToolChoice{mode: "none"}
+	// ToolChoiceRequired forces the model to call at least one tool.
+	ToolChoiceRequired = ToolChoice{mode: "required"}
+)
+
+// ToolChoiceFunction forces the model to call the named tool.
+func ToolChoiceFunction(name string) ToolChoice {
+	return ToolChoice{mode: "function", function: name}
+}
+
+func (c ToolChoice) MarshalJSON() ([]byte, error) {
+	if c.mode != "function" {
+		return json.Marshal(c.mode)
+	}
+
+	return json.Marshal(toolDefinition{
+		Type:     "function",
+		Function: functionDefinition{Name: c.function},
+	})
+}
+
+// ToolCall is a call to a tool requested by the model.
+type ToolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall holds the name of the called tool and its JSON arguments.
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// DecodeArguments decodes the JSON arguments of the call into v.
+func (c ToolCall) DecodeArguments(v any) error {
+	if err := json.Unmarshal([]byte(c.Function.Arguments), v); err != nil {
+		return fmt.Errorf("error unmarshaling arguments of tool %s: %w", c.Function.Name, err)
+	}
+
+	return nil
+}
+
+type functionDefinition struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  *schema.Schema `json:"parameters,omitempty"`
+}
+
+type toolDefinition struct {
+	Type     string             `json:"type"`
+	Function functionDefinition `json:"function"`
+}
+
+func newToolDefinitions(tools []Tool) ([]toolDefinition, error) {
+	definitions := make([]toolDefinition, 0, len(tools))
+	for _, tool := range tools {
+		parameters, err := tool.Parameters()
+		if err != nil {
+			return nil, fmt.Errorf("error generating schema of tool %s: %w", tool.Name(), err)
+		}
+
+		definitions = append(definitions, toolDefinition{
+			Type: "function",
+			Function: functionDefinition{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  parameters,
+			},
+		})
+	}
+
+	return definitions, nil
+}
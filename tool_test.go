@@ -0,0 +1,168 @@
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/orixa-group/open-router/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type weatherArgs struct {
+	City string `json:"city"`
+	Unit string `json:"unit,omitempty"`
+}
+
+type weatherResult struct {
+	Temperature int `json:"temperature"`
+}
+
+func weatherTool() Tool {
+	return NewTool("get_weather", "Get the current weather of a city", func(ctx context.Context, args weatherArgs) (weatherResult, error) {
+		if args.City == "" {
+			return weatherResult{}, errors.New("missing city")
+		}
+		return weatherResult{Temperature: len(args.City)}, nil
+	})
+}
+
+func TestNewTool(t *testing.T) {
+	tool := weatherTool()
+
+	assert.Equal(t, "get_weather", tool.Name())
+	assert.Equal(t, "Get the current weather of a city", tool.Description())
+
+	params, err := tool.Parameters()
+	assert.NoError(t, err)
+	assert.Equal(t, schema.Object, params.Type)
+	assert.Contains(t, params.Properties, "city")
+	assert.Equal(t, []string{"city"}, params.Required)
+
+	res, err := tool.Call(context.Background(), `{"city":"Paris"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"temperature":5}`, res)
+
+	_, err = tool.Call(context.Background(), `{"city":`)
+	assert.ErrorContains(t, err, "error unmarshaling arguments of tool get_weather")
+
+	_, err = tool.Call(context.Background(), `{}`)
+	assert.EqualError(t, err, "missing city")
+
+	t.Run("string result", func(t *testing.T) {
+		tool := NewTool("echo", "", func(ctx context.Context, args weatherArgs) (string, error) {
+			return args.City, nil
+		})
+
+		res, err := tool.Call(context.Background(), `{"city":"Paris"}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "Paris", res)
+	})
+
+	t.Run("non struct arguments", func(t *testing.T) {
+		tool := NewTool("bad", "", func(ctx context.Context, args string) (string, error) {
+			return args, nil
+		})
+
+		_, err := tool.Parameters()
+		assert.ErrorContains(t, err, "tool arguments must be a struct")
+	})
+}
+
+func TestToolChoice_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		choice ToolChoice
+		want   string
+	}{
+		{ToolChoiceAuto, `"auto"`},
+		{ToolChoiceNone, `"none"`},
+		{ToolChoiceRequired, `"required"`},
+		{ToolChoiceFunction("get_weather"), `{"type":"function","function":{"name":"get_weather"}}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.choice)
+		assert.NoError(t, err)
+		assert.JSONEq(t, tt.want, string(data))
+	}
+}
+
+func TestChatCompletionRequest_Tools(t *testing.T) {
+	type testResponse struct {
+		Answer string `json:"answer"`
+	}
+
+	req := ChatCompletion[testResponse]().
+		Use(ModelGemini2_5FlashLite).
+		WithTools(weatherTool()).
+		WithToolChoice(ToolChoiceRequired).
+		WithParallelToolCalls(true)
+
+	data, err := json.Marshal(req)
+	assert.NoError(t, err)
+
+	var body struct {
+		Tools []struct {
+			Type     string `json:"type"`
+			Function struct {
+				Name        string         `json:"name"`
+				Description string         `json:"description"`
+				Parameters  map[string]any `json:"parameters"`
+			} `json:"function"`
+		} `json:"tools"`
+		ToolChoice        string `json:"tool_choice"`
+		ParallelToolCalls bool   `json:"parallel_tool_calls"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &body))
+	if assert.Len(t, body.Tools, 1) {
+		assert.Equal(t, "function", body.Tools[0].Type)
+		assert.Equal(t, "get_weather", body.Tools[0].Function.Name)
+		assert.Equal(t, "object", body.Tools[0].Function.Parameters["type"])
+	}
+	assert.Equal(t, "required", body.ToolChoice)
+	assert.True(t, body.ParallelToolCalls)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"choices":[{"finish_reason":"tool_calls","message":{"content":"","tool_calls":[
+			{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}},
+			{"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Tokyo\"}"}}
+		]}}]}`))
+	}))
+	defer server.Close()
+
+	res, err := req.GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+	assert.NoError(t, err)
+	assert.Nil(t, res.Value)
+	assert.Equal(t, FinishReasonToolCalls, res.FinishReason)
+	if assert.Len(t, res.ToolCalls, 2) {
+		assert.Equal(t, "call_2", res.ToolCalls[1].ID)
+
+		var args weatherArgs
+		assert.NoError(t, res.ToolCalls[1].DecodeArguments(&args))
+		assert.Equal(t, weatherArgs{City: "Tokyo"}, args)
+	}
+
+	_, err = req.GenerateContentWith(NewClient(WithBaseURL(server.URL)))
+	assert.ErrorContains(t, err, "the model called tools instead of answering")
+
+	t.Run("text along tool calls", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":"gen-1","choices":[{"finish_reason":"tool_calls","message":{"content":"Let me check the weather.","tool_calls":[
+				{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}
+			]}}]}`))
+		}))
+		defer server.Close()
+
+		res, err := req.GenerateResponse(context.Background(), NewClient(WithBaseURL(server.URL)))
+
+		assert.NoError(t, err)
+		assert.Nil(t, res.Value)
+		assert.Equal(t, "gen-1", res.ID)
+		assert.Equal(t, "Let me check the weather.", res.Content)
+		assert.Len(t, res.ToolCalls, 1)
+		assert.Equal(t, "Let me check the weather.", res.Message().Content)
+	})
+}